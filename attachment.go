@@ -0,0 +1,101 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// IssueAttachmentGet returns the content of the issue attachment.
+//
+// The base64-encoded content is decoded while reading, without buffering the whole file.
+// The caller must Close the returned reader.
+func (c Client) IssueAttachmentGet(ctx context.Context, attachmentID int) (io.ReadCloser, error) {
+	const method = "mc_issue_attachment_get"
+	resp, err := c.post(ctx, method,
+		IssueAttachmentGetRequest{Auth: c.auth, IssueAttachmentID: attachmentID})
+	if err != nil {
+		return nil, c.redactor.Error(err)
+	}
+	br := bufio.NewReader(resp.Body)
+	if err := findReturn(method, br); err != nil {
+		resp.Body.Close()
+		return nil, c.redactor.Error(err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{base64.NewDecoder(base64.StdEncoding, &xmlText{r: br}), resp.Body}, nil
+}
+
+// ProjectAttachmentAdd uploads the content as a new attachment (document) of the project.
+//
+// The content is streamed, respecting MaxAttachmentSize and calling Progress.
+func (c Client) ProjectAttachmentAdd(ctx context.Context, projectID int, name, title, description, fileType string, content io.Reader) (int, error) {
+	r, err := c.attachmentReader(content)
+	if err != nil {
+		return 0, err
+	}
+	var resp ProjectAttachmentAddResponse
+	if err := c.callStream(ctx, "mc_project_attachment_add",
+		ProjectAttachmentAddRequest{Auth: c.auth, ProjectID: projectID,
+			Name: name, Title: title, Description: description, FileType: fileType,
+			Content: r},
+		&resp); err != nil {
+		return 0, err
+	}
+	return resp.Return, nil
+}
+
+// attachmentReader wraps content to enforce MaxAttachmentSize and call Progress.
+//
+// The size of content is checked in advance, if it is known.
+func (c Client) attachmentReader(content io.Reader) (Reader, error) {
+	if c.MaxAttachmentSize > 0 {
+		size := int64(-1)
+		switch x := content.(type) {
+		case interface{ Size() int64 }: // bytes.Reader, strings.Reader
+			size = x.Size()
+		case *os.File:
+			if fi, err := x.Stat(); err == nil && fi.Mode().IsRegular() {
+				size = fi.Size()
+			}
+		}
+		if size > c.MaxAttachmentSize {
+			return Reader{}, fmt.Errorf("%d bytes is more than %d: %w", size, c.MaxAttachmentSize, ErrAttachmentTooLarge)
+		}
+	}
+	if c.MaxAttachmentSize <= 0 && c.Progress == nil {
+		return Reader{content}, nil
+	}
+	return Reader{&uploadReader{Reader: content, max: c.MaxAttachmentSize, progress: c.Progress}}, nil
+}
+
+// uploadReader counts the read bytes, reporting them to progress,
+// and returns ErrAttachmentTooLarge after reading more than max bytes.
+type uploadReader struct {
+	io.Reader
+	progress func(int64)
+	max, n   int64
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	if r.max > 0 && r.n > r.max {
+		return n, fmt.Errorf("more than %d bytes: %w", r.max, ErrAttachmentTooLarge)
+	}
+	if r.progress != nil && n > 0 {
+		r.progress(r.n)
+	}
+	return n, err
+}
+
+// vim: set fileencoding=utf-8 noet:
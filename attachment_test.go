@@ -0,0 +1,107 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssueAttachmentGet(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{})
+	content := bytes.Repeat([]byte("árvíztűrő tükörfúrógép\x00\xff\n"), 1000)
+	attID, err := srv.AddAttachment(issueID, "a.bin", "application/octet-stream", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := cl.IssueAttachmentGet(ctx, attID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("got %d bytes, wanted %d", len(got), len(content))
+	}
+
+	if _, err = srv.AddAttachment(issueID, "empty", "text/plain", nil); err != nil {
+		t.Fatal(err)
+	}
+	issue, _ := srv.Issue(issueID)
+	if rc, err = cl.IssueAttachmentGet(ctx, issue.Attachments[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = io.ReadAll(rc); err != nil || len(got) != 0 {
+		t.Errorf("empty: got %q, %+v", got, err)
+	}
+	rc.Close()
+
+	var fault *mantis.Fault
+	if _, err = cl.IssueAttachmentGet(ctx, attID+100); !errors.As(err, &fault) {
+		t.Errorf("missing attachment: got %+v, wanted a Fault", err)
+	}
+}
+
+func TestAttachmentUpload(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{})
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1<<16)
+	var progress int64
+	cl.Progress = func(n int64) {
+		if n < progress {
+			t.Errorf("progress went back from %d to %d", progress, n)
+		}
+		progress = n
+	}
+	// Hide the size of the content.
+	attID, err := cl.IssueAttachmentAdd(ctx, issueID, "big.bin", "application/octet-stream",
+		io.MultiReader(bytes.NewReader(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if progress != int64(len(content)) {
+		t.Errorf("progress: got %d, wanted %d", progress, len(content))
+	}
+	if got, _ := srv.AttachmentContent(attID); !bytes.Equal(got, content) {
+		t.Errorf("got %d bytes, wanted %d", len(got), len(content))
+	}
+
+	cl.Progress = nil
+	docID, err := cl.ProjectAttachmentAdd(ctx, projectID, "doc.txt", "title", "description", "text/plain",
+		bytes.NewReader(content[:100]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := srv.AttachmentContent(docID); !bytes.Equal(got, content[:100]) {
+		t.Errorf("project attachment: got %q", got)
+	}
+
+	cl.MaxAttachmentSize = int64(len(content) - 1)
+	if _, err = cl.IssueAttachmentAdd(ctx, issueID, "big.bin", "", bytes.NewReader(content)); !errors.Is(err, mantis.ErrAttachmentTooLarge) {
+		t.Errorf("known size: got %+v, wanted ErrAttachmentTooLarge", err)
+	}
+	if _, err = cl.IssueAttachmentAdd(ctx, issueID, "big.bin", "", io.MultiReader(bytes.NewReader(content))); !errors.Is(err, mantis.ErrAttachmentTooLarge) {
+		t.Errorf("streamed: got %+v, wanted ErrAttachmentTooLarge", err)
+	}
+	if issue, _ := srv.Issue(issueID); len(issue.Attachments) != 1 {
+		t.Errorf("got %d attachments, wanted 1", len(issue.Attachments))
+	}
+}
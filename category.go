@@ -0,0 +1,123 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ProjectCategoryAdd adds the category to the project, returning its ID.
+func (c Client) ProjectCategoryAdd(ctx context.Context, projectID int, name string) (int, error) {
+	var resp ProjectAddCategoryResponse
+	err := c.Call(ctx, "mc_project_add_category",
+		ProjectAddCategoryRequest{Auth: c.auth, ProjectID: projectID, CategoryName: name},
+		&resp)
+	return resp.Return, err
+}
+
+// ProjectCategoryDelete deletes the category of the project.
+//
+// The inherited (global or parent project's) categories cannot be deleted this way.
+func (c Client) ProjectCategoryDelete(ctx context.Context, projectID int, name string) error {
+	var resp ProjectDeleteCategoryResponse
+	return c.Call(ctx, "mc_project_delete_category",
+		ProjectDeleteCategoryRequest{Auth: c.auth, ProjectID: projectID, CategoryName: name},
+		&resp)
+}
+
+// ProjectCategoryRename renames the category of the project,
+// setting its default handler to the user with the assignedTo ID (0 for none).
+func (c Client) ProjectCategoryRename(ctx context.Context, projectID int, name, newName string, assignedTo int) error {
+	var resp ProjectRenameCategoryByNameResponse
+	return c.Call(ctx, "mc_project_rename_category_by_name",
+		ProjectRenameCategoryByNameRequest{Auth: c.auth, ProjectID: projectID,
+			CategoryName: name, CategoryNameNew: newName, AssignedTo: assignedTo},
+		&resp)
+}
+
+// ProjectCategorySync makes the categories of the project match the given ones:
+// adds the missing and deletes the extra categories, returning them.
+//
+// The extra inherited (global or parent project's) categories cannot be deleted,
+// so these are left as is and returned as skipped.
+func (c Client) ProjectCategorySync(ctx context.Context, projectID int, categories []string) (added, deleted, skipped []string, err error) {
+	resp, err := c.GetCategoriesForProject(ctx, projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	inherited, err := c.inheritedCategories(ctx, projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, name := range categories {
+		if name == "" || slices.Contains(resp.Categories, name) || slices.Contains(added, name) {
+			continue
+		}
+		if _, err := c.ProjectCategoryAdd(ctx, projectID, name); err != nil {
+			return added, deleted, skipped, fmt.Errorf("add category %q: %w", name, err)
+		}
+		added = append(added, name)
+	}
+	for _, name := range resp.Categories {
+		if slices.Contains(categories, name) {
+			continue
+		}
+		if slices.Contains(inherited, name) {
+			skipped = append(skipped, name)
+			continue
+		}
+		if err := c.ProjectCategoryDelete(ctx, projectID, name); err != nil {
+			return added, deleted, skipped, fmt.Errorf("delete category %q: %w", name, err)
+		}
+		deleted = append(deleted, name)
+	}
+	return added, deleted, skipped, nil
+}
+
+// inheritedCategories returns the categories the project inherits:
+// its parent project's and, unless InheritGlobal is false, the global ones.
+func (c Client) inheritedCategories(ctx context.Context, projectID int) ([]string, error) {
+	projects, err := c.ProjectsGetUserAccessible(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var parentID int
+	var project ProjectData
+	var find func(parent int, pp []ProjectData) bool
+	find = func(parent int, pp []ProjectData) bool {
+		for _, p := range pp {
+			if p.ID == projectID {
+				parentID, project = parent, p
+				return true
+			}
+			if find(p.ID, p.Subprojects) {
+				return true
+			}
+		}
+		return false
+	}
+	find(AllProjects, projects)
+
+	var inherited []string
+	if parentID != AllProjects {
+		resp, err := c.GetCategoriesForProject(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("categories of the parent project %d: %w", parentID, err)
+		}
+		inherited = append(inherited, resp.Categories...)
+	}
+	if project.InheritGlobal == nil || *project.InheritGlobal {
+		resp, err := c.GetCategoriesForProject(ctx, AllProjects)
+		if err != nil {
+			return nil, fmt.Errorf("global categories: %w", err)
+		}
+		inherited = append(inherited, resp.Categories...)
+	}
+	return inherited, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
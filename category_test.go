@@ -0,0 +1,106 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestProjectCategories(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	srv.AddCategory(projectID, "general")
+	category := "general"
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{Category: &category})
+	categories := func() []string {
+		t.Helper()
+		resp, err := cl.GetCategoriesForProject(ctx, projectID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Categories
+	}
+
+	if _, err := cl.ProjectCategoryAdd(ctx, projectID, "ui"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ProjectCategoryAdd(ctx, projectID, "ui"); err == nil {
+		t.Error("got nil error for a duplicate category")
+	}
+	if err := cl.ProjectCategoryRename(ctx, projectID, "general", "backend", cl.User.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := categories(); !slices.Equal(got, []string{"backend", "ui"}) {
+		t.Errorf("got %q", got)
+	}
+	if issue, _ := srv.Issue(issueID); *issue.Category != "backend" {
+		t.Errorf("issue's category is %q, wanted backend", *issue.Category)
+	}
+	if err := cl.ProjectCategoryDelete(ctx, projectID, "ui"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.ProjectCategoryDelete(ctx, projectID, "ui"); err == nil {
+		t.Error("got nil error for deleting a deleted category")
+	}
+
+	added, deleted, skipped, err := cl.ProjectCategorySync(ctx, projectID, []string{"docs", "ui", "docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(added, []string{"docs", "ui"}) || !slices.Equal(deleted, []string{"backend"}) || len(skipped) != 0 {
+		t.Errorf("got added=%q deleted=%q skipped=%q", added, deleted, skipped)
+	}
+	if got := categories(); !slices.Equal(got, []string{"docs", "ui"}) {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestProjectCategorySyncInherited(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	srv.AddCategory(mantis.AllProjects, "General")
+	parentID := srv.AddProject(mantis.ProjectData{Name: "parent"}, 0)
+	srv.AddCategory(parentID, "shared")
+	projectID := srv.AddProject(mantis.ProjectData{Name: "sub"}, parentID)
+	srv.AddCategory(projectID, "old")
+	inherit := false
+	ownID := srv.AddProject(mantis.ProjectData{Name: "own", InheritGlobal: &inherit}, 0)
+	srv.AddCategory(ownID, "mine")
+
+	resp, err := cl.GetCategoriesForProject(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"old", "shared", "General"}; !slices.Equal(resp.Categories, want) {
+		t.Errorf("got %q, wanted %q", resp.Categories, want)
+	}
+
+	added, deleted, skipped, err := cl.ProjectCategorySync(ctx, projectID, []string{"new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(added, []string{"new"}) || !slices.Equal(deleted, []string{"old"}) ||
+		!slices.Equal(skipped, []string{"shared", "General"}) {
+		t.Errorf("got added=%q deleted=%q skipped=%q", added, deleted, skipped)
+	}
+	if resp, err = cl.GetCategoriesForProject(ctx, projectID); err != nil {
+		t.Fatal(err)
+	} else if want := []string{"new", "shared", "General"}; !slices.Equal(resp.Categories, want) {
+		t.Errorf("got %q, wanted %q", resp.Categories, want)
+	}
+
+	if _, deleted, skipped, err = cl.ProjectCategorySync(ctx, ownID, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(deleted, []string{"mine"}) || len(skipped) != 0 {
+		t.Errorf("got deleted=%q skipped=%q", deleted, skipped)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package changelog collects the resolved issues of the versions of a Mantis project,
+// by their "fixed in version" field, and renders them as release notes;
+// and the issues of the unreleased versions, by their "target version" field, as a roadmap.
+package changelog
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+// Options of New.
+type Options struct {
+	// Versions are the names of the versions to include, all if empty.
+	Versions []string
+	// GroupBy is "category" (the default) or "severity".
+	GroupBy string
+	// ResolvedStatus is the first status of the resolved issues, mantis.DefaultResolvedStatus if zero.
+	ResolvedStatus int
+	// PerPage is the page size of the searches, mantis.DefaultPerPage if zero.
+	PerPage int
+}
+
+// Changelog is the list of the versions, the latest first.
+type Changelog struct {
+	// Title is the heading of the rendered changelog, such as the name of the project.
+	Title    string    `json:"title,omitempty"`
+	Versions []Version `json:"versions"`
+}
+
+// Version is a version of the project with its resolved issues.
+type Version struct {
+	Date        time.Time `json:"date"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Groups      []Group   `json:"groups"`
+	ID          int       `json:"id"`
+	Released    bool      `json:"released"`
+}
+
+// Group is the issues of a category or a severity.
+type Group struct {
+	Name   string  `json:"name"`
+	Issues []Issue `json:"issues"`
+}
+
+// Issue is a resolved issue.
+type Issue struct {
+	Summary    string `json:"summary"`
+	Category   string `json:"category"`
+	Severity   string `json:"severity"`
+	Resolution string `json:"resolution"`
+	ID         int    `json:"id"`
+}
+
+// New collects the resolved issues of the versions of the project.
+//
+// When all versions are requested, the versions without resolved issues are left out.
+func New(ctx context.Context, cl mantis.Client, projectID int, opts Options) (Changelog, error) {
+	switch opts.GroupBy {
+	case "":
+		opts.GroupBy = "category"
+	case "category", "severity":
+	default:
+		return Changelog{}, fmt.Errorf("unknown grouping %q", opts.GroupBy)
+	}
+	if opts.ResolvedStatus <= 0 {
+		opts.ResolvedStatus = mantis.DefaultResolvedStatus
+	}
+	versions, err := cl.ProjectVersionsList(ctx, projectID)
+	if err != nil {
+		return Changelog{}, err
+	}
+	if len(opts.Versions) != 0 {
+		for _, name := range opts.Versions {
+			if !slices.ContainsFunc(versions, func(v mantis.ProjectVersionData) bool { return v.Name == name }) {
+				return Changelog{}, fmt.Errorf("version %q of project %d: %w", name, projectID, mantis.ErrVersionNotFound)
+			}
+		}
+		versions = slices.DeleteFunc(versions, func(v mantis.ProjectVersionData) bool {
+			return !slices.Contains(opts.Versions, v.Name)
+		})
+	}
+	slices.SortStableFunc(versions, func(a, b mantis.ProjectVersionData) int {
+		return cmp.Or(dateOf(b).Compare(dateOf(a)), cmp.Compare(b.ID, a.ID))
+	})
+
+	var c Changelog
+	for _, v := range versions {
+		version := Version{ID: v.ID, Name: v.Name, Description: v.Description,
+			Date: dateOf(v), Released: v.Released}
+		// Most of the fixed issues are closed, which Mantis hides by default.
+		filter := mantis.FilterSearchData{ProjectID: []int{projectID}, FixedInVersion: []string{v.Name},
+			HideStatusID: []int{mantis.MetaFilterNone}}
+		for h, err := range cl.AllFilterSearchIssueHeaders(ctx, filter, opts.PerPage) {
+			if err != nil {
+				return c, fmt.Errorf("search the issues of version %q: %w", v.Name, err)
+			}
+			if h.Status < opts.ResolvedStatus {
+				continue
+			}
+			issue := Issue{ID: int(h.ID), Summary: h.Summary, Category: h.Category}
+			if issue.Severity, err = enumName(ctx, cl, mantis.EnumSeverity, h.Severity); err != nil {
+				return c, err
+			}
+			if issue.Resolution, err = enumName(ctx, cl, mantis.EnumResolution, h.Resolution); err != nil {
+				return c, err
+			}
+			key := issue.Category
+			if opts.GroupBy == "severity" {
+				key = issue.Severity
+			}
+			i := slices.IndexFunc(version.Groups, func(g Group) bool { return g.Name == key })
+			if i < 0 {
+				i = len(version.Groups)
+				version.Groups = append(version.Groups, Group{Name: key})
+			}
+			version.Groups[i].Issues = append(version.Groups[i].Issues, issue)
+		}
+		if len(version.Groups) == 0 && len(opts.Versions) == 0 {
+			continue
+		}
+		slices.SortFunc(version.Groups, func(a, b Group) int { return cmp.Compare(a.Name, b.Name) })
+		for _, g := range version.Groups {
+			slices.SortFunc(g.Issues, func(a, b Issue) int { return cmp.Compare(a.ID, b.ID) })
+		}
+		c.Versions = append(c.Versions, version)
+	}
+	return c, nil
+}
+
+// enumName returns the name of the enum value, or its ID if unknown.
+func enumName(ctx context.Context, cl mantis.Client, enum mantis.Enum, id int) (string, error) {
+	name, err := cl.Enums().Name(ctx, enum, id)
+	if errors.Is(err, mantis.ErrUnknownEnumValue) {
+		return strconv.Itoa(id), nil
+	}
+	return name, err
+}
+
+func dateOf(v mantis.ProjectVersionData) time.Time {
+	if v.DateOrder.IsZero() {
+		return time.Time{}
+	}
+	return time.Time(*v.DateOrder)
+}
+
+// heading returns the name and the date of the version, or "unreleased".
+func (v Version) heading() string {
+	if !v.Released || v.Date.IsZero() {
+		return v.Name + " (unreleased)"
+	}
+	return v.Name + " (" + v.Date.Format(time.DateOnly) + ")"
+}
+
+// WriteMarkdown writes the changelog as Markdown.
+func (c Changelog) WriteMarkdown(w io.Writer) error {
+	var buf strings.Builder
+	if c.Title != "" {
+		fmt.Fprintf(&buf, "# %s\n\n", c.Title)
+	}
+	for _, v := range c.Versions {
+		fmt.Fprintf(&buf, "## %s\n\n", v.heading())
+		if v.Description != "" {
+			fmt.Fprintf(&buf, "%s\n\n", strings.TrimSpace(v.Description))
+		}
+		if len(v.Groups) == 0 {
+			buf.WriteString("No changes.\n\n")
+		}
+		for _, g := range v.Groups {
+			fmt.Fprintf(&buf, "### %s\n\n", cmp.Or(g.Name, "(none)"))
+			for _, issue := range g.Issues {
+				fmt.Fprintf(&buf, "- #%d %s (%s)\n", issue.ID, markdownEscape(issue.Summary), issue.Severity)
+			}
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// markdownEscape escapes the Markdown metacharacters of the single line s,
+// joining its lines with spaces.
+func markdownEscape(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	var buf strings.Builder
+	for i, r := range s {
+		if strings.ContainsRune("\\`*_[]()#<>|!~", r) || i == 0 && (r == '-' || r == '+') {
+			buf.WriteByte('\\')
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+var htmlTemplate = template.Must(template.New("changelog").Parse(`{{if .Title}}<h1>{{.Title}}</h1>
+{{end}}{{range .Versions}}<h2>{{.Heading}}</h2>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}{{if not .Groups}}<p>No changes.</p>
+{{end}}{{range .Groups}}<h3>{{or .Name "(none)"}}</h3>
+<ul>
+{{range .Issues}}<li>#{{.ID}} {{.Summary}} ({{.Severity}})</li>
+{{end}}</ul>
+{{end}}{{end}}`))
+
+// WriteHTML writes the changelog as an HTML fragment.
+func (c Changelog) WriteHTML(w io.Writer) error {
+	type htmlVersion struct {
+		Version
+		Heading string
+	}
+	data := struct {
+		Title    string
+		Versions []htmlVersion
+	}{Title: c.Title}
+	for _, v := range c.Versions {
+		data.Versions = append(data.Versions, htmlVersion{Version: v, Heading: v.heading()})
+	}
+	return htmlTemplate.Execute(w, data)
+}
+
+// vim: set fileencoding=utf-8 noet:
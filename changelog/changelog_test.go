@@ -0,0 +1,102 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changelog_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/changelog"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestChangelog(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	srv.AddCategory(projectID, "backend")
+	srv.AddCategory(projectID, "ui")
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"1.0", "1.1", "1.2"} {
+		date := mantis.Time(day.AddDate(0, i, 0))
+		if _, err := srv.AddVersion(mantis.ProjectVersionData{ProjectID: projectID,
+			Name: name, Description: "Version " + name, DateOrder: &date, Released: name != "1.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, x := range []struct {
+		category, summary, version string
+		status, severity           int
+	}{
+		{"ui", "<b>bold</b> button", "1.0", 80, 20},
+		{"backend", "crash on start", "1.0", 90, 70},
+		{"backend", "still open", "1.0", 50, 50},
+		{"backend", "*slow* query [db]", "1.1", 80, 50},
+	} {
+		srv.NewIssue(t, projectID, mantis.IssueData{Category: &x.category, Summary: &x.summary,
+			FixedInVersion: &x.version,
+			Status:         &mantis.ObjectRef{ID: x.status}, Severity: &mantis.ObjectRef{ID: x.severity}})
+	}
+
+	c, err := changelog.New(ctx, cl, projectID, changelog.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Title = "proj"
+	var buf strings.Builder
+	if err = c.WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `# proj
+
+## 1.1 (2026-02-01)
+
+Version 1.1
+
+### backend
+
+- #4 \*slow\* query \[db\] (minor)
+
+## 1.0 (2026-01-01)
+
+Version 1.0
+
+### backend
+
+- #2 crash on start (crash)
+
+### ui
+
+- #1 \<b\>bold\</b\> button (trivial)
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+
+	buf.Reset()
+	if err = c.WriteHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.Contains(got, "<h2>1.0 (2026-01-01)</h2>") ||
+		!strings.Contains(got, "<li>#1 &lt;b&gt;bold&lt;/b&gt; button (trivial)</li>") {
+		t.Errorf("got\n%s", got)
+	}
+
+	if c, err = changelog.New(ctx, cl, projectID, changelog.Options{Versions: []string{"1.0", "1.2"}, GroupBy: "severity"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Versions) != 2 || c.Versions[0].Name != "1.2" || len(c.Versions[0].Groups) != 0 ||
+		len(c.Versions[1].Groups) != 2 || c.Versions[1].Groups[0].Name != "crash" {
+		t.Errorf("got %+v", c.Versions)
+	}
+	if _, err = changelog.New(ctx, cl, projectID, changelog.Options{Versions: []string{"2.0"}}); !errors.Is(err, mantis.ErrVersionNotFound) {
+		t.Errorf("got %+v, wanted ErrVersionNotFound", err)
+	}
+}
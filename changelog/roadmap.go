@@ -0,0 +1,154 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changelog
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+// Roadmap is the list of the unreleased versions, the earliest first.
+type Roadmap struct {
+	// Title is the heading of the rendered roadmap, such as the name of the project.
+	Title    string           `json:"title,omitempty"`
+	Versions []RoadmapVersion `json:"versions"`
+}
+
+// RoadmapVersion is an unreleased version with the issues targeting it.
+type RoadmapVersion struct {
+	Date        time.Time      `json:"date"`
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Issues      []RoadmapIssue `json:"issues"`
+	ID          int            `json:"id"`
+	// Resolved is the number of the resolved issues.
+	Resolved int `json:"resolved"`
+	// Percent is the completion percentage: the ratio of the resolved issues.
+	Percent int `json:"percent"`
+}
+
+// RoadmapIssue is an issue targeting a version.
+type RoadmapIssue struct {
+	Summary  string `json:"summary"`
+	Category string `json:"category"`
+	Status   string `json:"status"`
+	ID       int    `json:"id"`
+	Resolved bool   `json:"resolved"`
+}
+
+// NewRoadmap collects the issues of the unreleased versions of the project, by their target version.
+//
+// Of the options, only Versions, ResolvedStatus and PerPage are used.
+func NewRoadmap(ctx context.Context, cl mantis.Client, projectID int, opts Options) (Roadmap, error) {
+	if opts.ResolvedStatus <= 0 {
+		opts.ResolvedStatus = mantis.DefaultResolvedStatus
+	}
+	versions, err := cl.ProjectUnreleasedVersions(ctx, projectID)
+	if err != nil {
+		return Roadmap{}, err
+	}
+	if len(opts.Versions) != 0 {
+		for _, name := range opts.Versions {
+			if !slices.ContainsFunc(versions, func(v mantis.ProjectVersionData) bool { return v.Name == name }) {
+				return Roadmap{}, fmt.Errorf("unreleased version %q of project %d: %w", name, projectID, mantis.ErrVersionNotFound)
+			}
+		}
+		versions = slices.DeleteFunc(versions, func(v mantis.ProjectVersionData) bool {
+			return !slices.Contains(opts.Versions, v.Name)
+		})
+	}
+	slices.SortStableFunc(versions, func(a, b mantis.ProjectVersionData) int {
+		return cmp.Or(dateOf(a).Compare(dateOf(b)), cmp.Compare(a.ID, b.ID))
+	})
+
+	var r Roadmap
+	for _, v := range versions {
+		version := RoadmapVersion{ID: v.ID, Name: v.Name, Description: v.Description, Date: dateOf(v)}
+		// The closed issues count as resolved, so they must not be hidden.
+		filter := mantis.FilterSearchData{ProjectID: []int{projectID}, TargetVersion: []string{v.Name},
+			HideStatusID: []int{mantis.MetaFilterNone}}
+		for h, err := range cl.AllFilterSearchIssueHeaders(ctx, filter, opts.PerPage) {
+			if err != nil {
+				return r, fmt.Errorf("search the issues of version %q: %w", v.Name, err)
+			}
+			issue := RoadmapIssue{ID: int(h.ID), Summary: h.Summary, Category: h.Category,
+				Resolved: h.Status >= opts.ResolvedStatus}
+			if issue.Status, err = enumName(ctx, cl, mantis.EnumStatus, h.Status); err != nil {
+				return r, err
+			}
+			if issue.Resolved {
+				version.Resolved++
+			}
+			version.Issues = append(version.Issues, issue)
+		}
+		slices.SortFunc(version.Issues, func(a, b RoadmapIssue) int { return cmp.Compare(a.ID, b.ID) })
+		if len(version.Issues) != 0 {
+			version.Percent = version.Resolved * 100 / len(version.Issues)
+		}
+		r.Versions = append(r.Versions, version)
+	}
+	return r, nil
+}
+
+// progress returns the number of the resolved and all the issues, with the percentage.
+func (v RoadmapVersion) progress() string {
+	return fmt.Sprintf("%d of %d issues resolved (%d%%)", v.Resolved, len(v.Issues), v.Percent)
+}
+
+// WriteTable writes the roadmap as aligned text tables, one per version.
+func (r Roadmap) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	for i, v := range r.Versions {
+		if i != 0 {
+			fmt.Fprintln(tw)
+		}
+		fmt.Fprintf(tw, "%s (%s): %s\n", v.Name, v.Date.Format(time.DateOnly), v.progress())
+		if len(v.Issues) == 0 {
+			continue
+		}
+		fmt.Fprintln(tw, "ISSUE\tSTATUS\tCATEGORY\tSUMMARY")
+		for _, issue := range v.Issues {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", issue.ID, issue.Status, issue.Category, issue.Summary)
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the roadmap as Markdown, with the issues as task lists.
+func (r Roadmap) WriteMarkdown(w io.Writer) error {
+	var buf strings.Builder
+	if r.Title != "" {
+		fmt.Fprintf(&buf, "# %s\n\n", r.Title)
+	}
+	for _, v := range r.Versions {
+		fmt.Fprintf(&buf, "## %s (%s)\n\n", v.Name, v.Date.Format(time.DateOnly))
+		if v.Description != "" {
+			fmt.Fprintf(&buf, "%s\n\n", strings.TrimSpace(v.Description))
+		}
+		fmt.Fprintf(&buf, "%s.\n\n", v.progress())
+		for _, issue := range v.Issues {
+			check := " "
+			if issue.Resolved {
+				check = "x"
+			}
+			fmt.Fprintf(&buf, "- [%s] #%d %s (%s)\n", check, issue.ID, markdownEscape(issue.Summary), issue.Status)
+		}
+		if len(v.Issues) != 0 {
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// vim: set fileencoding=utf-8 noet:
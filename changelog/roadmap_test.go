@@ -0,0 +1,93 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package changelog_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/changelog"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestRoadmap(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"1.0", "1.2", "1.1"} {
+		date := mantis.Time(day.AddDate(0, i, 0))
+		if name == "1.1" {
+			date = mantis.Time(day.AddDate(0, 1, -1))
+		}
+		if _, err := srv.AddVersion(mantis.ProjectVersionData{ProjectID: projectID,
+			Name: name, DateOrder: &date, Released: name == "1.0"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, x := range []struct {
+		summary, version string
+		status           int
+	}{
+		{"done", "1.1", 80},
+		{"closed", "1.1", 90},
+		{"in progress", "1.1", 50},
+		{"old", "1.0", 10},
+	} {
+		srv.NewIssue(t, projectID, mantis.IssueData{Summary: &x.summary,
+			TargetVersion: &x.version, Status: &mantis.ObjectRef{ID: x.status}})
+	}
+
+	r, err := changelog.NewRoadmap(ctx, cl, projectID, changelog.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Versions) != 2 || r.Versions[0].Name != "1.1" || r.Versions[1].Name != "1.2" {
+		t.Fatalf("got %+v", r.Versions)
+	}
+	if v := r.Versions[0]; len(v.Issues) != 3 || v.Resolved != 2 || v.Percent != 66 {
+		t.Errorf("got %+v", v)
+	}
+	if v := r.Versions[1]; len(v.Issues) != 0 || v.Percent != 0 {
+		t.Errorf("got %+v", v)
+	}
+
+	var buf strings.Builder
+	if err = r.WriteMarkdown(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `## 1.1 (2026-01-31)
+
+2 of 3 issues resolved (66%).
+
+- [x] #1 done (resolved)
+- [x] #2 closed (closed)
+- [ ] #3 in progress (assigned)
+
+## 1.2 (2026-02-01)
+
+0 of 0 issues resolved (0%).
+
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+	buf.Reset()
+	if err = r.WriteTable(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, "1.1 (2026-01-31): 2 of 3 issues resolved (66%)\nISSUE  STATUS    CATEGORY  SUMMARY\n") ||
+		!strings.Contains(got, "\n3      assigned            in progress\n") {
+		t.Errorf("got\n%s", got)
+	}
+
+	if _, err = changelog.NewRoadmap(ctx, cl, projectID, changelog.Options{Versions: []string{"1.0"}}); !errors.Is(err, mantis.ErrVersionNotFound) {
+		t.Errorf("got %+v, wanted ErrVersionNotFound", err)
+	}
+}
@@ -7,7 +7,6 @@ package mantis
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -17,6 +16,7 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/UNO-SOFT/zlog/v2"
@@ -26,7 +26,8 @@ import (
 
 var logger = slog.Default()
 
-func SetLogger(lgr *slog.Logger) { logger = lgr }
+// SetLogger sets the package-level logger, redacting the secrets.
+func SetLogger(lgr *slog.Logger) { logger = redactor{}.Logger(lgr) }
 
 func NewWithHTTPClient(ctx context.Context, c *http.Client, baseURL, username, password string) (Client, error) {
 	select {
@@ -40,7 +41,7 @@ func NewWithHTTPClient(ctx context.Context, c *http.Client, baseURL, username, p
 	if c.Transport == nil {
 		c.Transport = http.DefaultTransport
 	}
-	c.Transport = soaphlp.NewTranspport(c.Transport)
+	c.Transport = faultTransport{soaphlp.NewTranspport(c.Transport)}
 	if c.Jar == nil {
 		var err error
 		if c.Jar, err = cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List}); err != nil {
@@ -48,17 +49,16 @@ func NewWithHTTPClient(ctx context.Context, c *http.Client, baseURL, username, p
 		}
 	}
 	cl := Client{
-		Caller: soaphlp.NewClient(
-			baseURL+"/api/soap/mantisconnect.php",
-			"http://www.mantisbt.org/bugs/api/soap/mantisconnect.php/",
-			c,
-		),
+		Caller:  soaphlp.NewClient(baseURL+soapPath, soapActionBase, c),
+		soapURL: baseURL + soapPath,
 		auth: Auth{
 			Username: username,
 			Password: password,
 		},
 		httpClient: c, restURL: baseURL + "/api/rest/index.php",
+		enums: new(enumCache),
 	}
+	cl.redactor = newRedactor(authSecrets(cl.auth)...)
 	var err error
 	if cl.auth.IsAPIToken() {
 		cl.User, err = cl.Me(ctx)
@@ -71,6 +71,11 @@ func NewWithHTTPClient(ctx context.Context, c *http.Client, baseURL, username, p
 	return cl, err
 }
 
+const (
+	soapPath       = "/api/soap/mantisconnect.php"
+	soapActionBase = "http://www.mantisbt.org/bugs/api/soap/mantisconnect.php/"
+)
+
 func New(ctx context.Context, baseURL, username, password string) (Client, error) {
 	return NewWithHTTPClient(ctx, nil, baseURL, username, password)
 }
@@ -79,12 +84,33 @@ type Client struct {
 	soaphlp.Caller
 	httpClient *http.Client
 	*slog.Logger
-	User    AccountData
-	auth    Auth
-	restURL string
+	User     AccountData
+	auth     Auth
+	redactor redactor
+	restURL  string
+	soapURL  string
+	// Progress, if not nil, is called with the number of bytes
+	// of the attachment content read so far, while uploading.
+	Progress func(read int64)
+	// MaxAttachmentSize, if positive, limits the size of the uploaded attachments.
+	MaxAttachmentSize int64
+	// enums caches the enumerations for Enums, shared by the copies of the Client.
+	enums *enumCache
+	// EnumTTL is the time Enums caches the values; zero means DefaultEnumTTL.
+	EnumTTL time.Duration
+	// Concurrency, if positive, limits the number of concurrent calls
+	// of the bulk operations - DefaultConcurrency otherwise.
+	Concurrency int
 }
 
+// Call the SOAP method with the request, decoding the answer into response.
+//
+// The returned errors never contain the credentials.
 func (c Client) Call(ctx context.Context, method string, request, response interface{}) error {
+	return c.redactor.Error(c.call(ctx, method, request, response))
+}
+
+func (c Client) call(ctx context.Context, method string, request, response interface{}) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -97,20 +123,27 @@ func (c Client) Call(ctx context.Context, method string, request, response inter
 	defer bufPool.Put(buf)
 
 	if err := xml.NewEncoder(buf).Encode(request); err != nil {
-		return fmt.Errorf("marshal %#v: %w", request, err)
+		return fmt.Errorf("marshal %s request: %w", method, err)
 	}
-	if zlog.SFromContext(ctx) == nil {
-		ctx = zlog.NewSContext(ctx, c.Logger)
+	lgr := c.Logger
+	if lgr == nil {
+		lgr = zlog.SFromContext(ctx)
 	}
+	ctx = zlog.NewSContext(ctx, c.redactor.Logger(lgr))
 	answ := bufPool.Get()
 	defer bufPool.Put(answ)
+	ctx, capture := withFaultCapture(ctx)
 	d, err := c.Caller.Call(ctx, answ, method, bytes.NewReader(buf.Bytes()))
 	if err != nil {
-		return fmt.Errorf("call %s: %w", buf.String(), err)
+		return callError(method, err, capture.body, answ.Bytes())
 	}
 	buf.Reset()
 	if err := d.Decode(response); err != nil {
-		return fmt.Errorf("decode response: %w", err)
+		if f := parseFault(answ.Bytes()); f != nil {
+			f.Method, f.err = method, err
+			return f
+		}
+		return fmt.Errorf("decode %s response: %w", method, err)
 	}
 	return nil
 }
@@ -154,7 +187,21 @@ func (c Client) ProjectIssues(ctx context.Context, projectID, page, perPage int)
 	return resp.Issues, err
 }
 
+// IssueUpdate updates the issue, after validating its custom fields.
+//
+// Mantis leaves the missing custom fields as is, so send only the changed ones:
+// the stored values may not fit their (changed) definitions anymore.
 func (c Client) IssueUpdate(ctx context.Context, issueID int, issue IssueData) (bool, error) {
+	if issue.Project != nil {
+		if err := c.validateCustomFields(ctx, issue.Project.ID, issue.CustomFields, false); err != nil {
+			return false, err
+		}
+	}
+	return c.issueUpdate(ctx, issueID, issue)
+}
+
+// issueUpdate calls mc_issue_update without validating the custom fields.
+func (c Client) issueUpdate(ctx context.Context, issueID int, issue IssueData) (bool, error) {
 	var resp IssueUpdateResponse
 	iID := IssueID(issueID)
 	issue.ID = &iID
@@ -167,7 +214,13 @@ func (c Client) IssueUpdate(ctx context.Context, issueID int, issue IssueData) (
 	return resp.Return, nil
 }
 
+// IssueAdd creates the issue, after validating its custom fields (if any), returning its ID.
 func (c Client) IssueAdd(ctx context.Context, issue IssueData) (int, error) {
+	if issue.Project != nil {
+		if err := c.validateCustomFields(ctx, issue.Project.ID, issue.CustomFields, true); err != nil {
+			return 0, err
+		}
+	}
 	var resp IssueAddResponse
 	if err := c.Call(ctx, "mc_issue_add",
 		IssueAddRequest{Auth: c.auth, Issue: issue},
@@ -178,12 +231,19 @@ func (c Client) IssueAdd(ctx context.Context, issue IssueData) (int, error) {
 	return resp.Return, nil
 }
 
+// IssueAttachmentAdd uploads the content as a new attachment of the issue.
+//
+// The content is streamed, respecting MaxAttachmentSize and calling Progress.
 func (c Client) IssueAttachmentAdd(ctx context.Context, issueID int, name, fileType string, content io.Reader) (int, error) {
+	r, err := c.attachmentReader(content)
+	if err != nil {
+		return 0, err
+	}
 	var resp IssueAttachmentAddResponse
-	if err := c.Call(ctx, "mc_issue_attachment_add",
+	if err := c.callStream(ctx, "mc_issue_attachment_add",
 		IssueAttachmentAddRequest{Auth: c.auth, IssueID: IssueID(issueID),
 			Name: name, FileType: fileType,
-			Content: Reader{content}},
+			Content: r},
 		&resp); err != nil {
 		return 0, err
 	}
@@ -201,6 +261,21 @@ func (c Client) IssueNoteAdd(ctx context.Context, issueID int, note IssueNoteDat
 	return resp.Return, nil
 }
 
+// IssueNoteUpdate updates the text (and the view state, if not nil) of the note with note.ID.
+func (c Client) IssueNoteUpdate(ctx context.Context, note IssueNoteData) error {
+	if note.ID == nil || *note.ID == 0 {
+		return fmt.Errorf("note ID is required")
+	}
+	var resp IssueNoteUpdateResponse
+	return c.Call(ctx, "mc_issue_note_update", IssueNoteUpdateRequest{Auth: c.auth, Note: note}, &resp)
+}
+
+// IssueNoteDelete deletes the note.
+func (c Client) IssueNoteDelete(ctx context.Context, noteID int) error {
+	var resp IssueNoteDeleteResponse
+	return c.Call(ctx, "mc_issue_note_delete", IssueNoteDeleteRequest{Auth: c.auth, IssueNoteID: noteID}, &resp)
+}
+
 func (c Client) IssueGet(ctx context.Context, issueID int) (IssueData, error) {
 	var resp IssueGetResponse
 	if err := c.Call(ctx, "mc_issue_get",
@@ -250,14 +325,14 @@ func (c Client) ProjectVersionAdd(ctx context.Context, projectID int, name, desc
 }
 func (c Client) ProjectVersionUpdate(ctx context.Context, version ProjectVersionData) error {
 	var resp ProjectVersionUpdateResponse
-	return c.Call(ctx, "mc_project_version_updateRequest",
+	return c.Call(ctx, "mc_project_version_update",
 		ProjectVersionUpdateRequest{Auth: c.auth, VersionID: version.ID, Version: version},
 		&resp,
 	)
 }
 func (c Client) ProjectVersionDelete(ctx context.Context, versionID int) error {
 	var resp ProjectVersionDeleteResponse
-	return c.Call(ctx, "mc_project_version_deleteRequest",
+	return c.Call(ctx, "mc_project_version_delete",
 		ProjectVersionDeleteRequest{Auth: c.auth, VersionID: versionID},
 		&resp)
 }
@@ -302,7 +377,7 @@ func (c Client) CreateAPIToken(ctx context.Context, name string) (string, error)
 
 	// SOAP
 	var resp UserTokenCreateResponse
-	err := c.Call(ctx, "mc_user_token_createRequest",
+	err := c.Call(ctx, "mc_user_token_create",
 		UserTokenCreateRequest{Auth: c.auth, TokenName: name},
 		&resp)
 	return resp.Return, err
@@ -319,37 +394,35 @@ func (c Client) Me(ctx context.Context) (user AccountData, err error) {
 func (c Client) restCall(ctx context.Context, response any, method, path string, body io.Reader) error {
 	u := c.restURL + path
 	if err := func() error {
-		URL, err := url.Parse(u)
-		if err != nil {
-			return err
-		}
-		if !c.auth.IsAPIToken() {
-			if URL.User == nil {
-				URL.User = url.UserPassword(c.auth.Username, c.auth.Password)
-			}
-		}
-		req, err := http.NewRequestWithContext(ctx, method, URL.String(), body)
+		req, err := http.NewRequestWithContext(ctx, method, u, body)
 		if err != nil {
 			return err
 		}
 		if c.auth.IsAPIToken() {
 			req.Header.Add("Authorization", c.auth.Password)
 		} else {
-			req.Header.Add("Authorization", "Basic "+base64.URLEncoding.EncodeToString([]byte(c.auth.Username+":"+c.auth.Password)))
+			req.SetBasicAuth(c.auth.Username, c.auth.Password)
 		}
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			return err
 		}
-		if resp.StatusCode >= 400 {
-			return fmt.Errorf("%s [%v]", resp.Status, req.Header)
+		defer resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized:
+			return fmt.Errorf("%s: %w", resp.Status, ErrLoginFailed)
+		case resp.StatusCode == http.StatusForbidden:
+			return fmt.Errorf("%s: %w", resp.Status, ErrAccessDenied)
+		case resp.StatusCode >= 400:
+			b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(b))
 		}
 		if response == nil {
 			return nil
 		}
 		return json.NewDecoder(resp.Body).Decode(response)
 	}(); err != nil {
-		return fmt.Errorf("%s: %w", u, err)
+		return c.redactor.Error(fmt.Errorf("%s %s: %w", method, u, err))
 	}
 	return nil
 
@@ -0,0 +1,111 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func downloadCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("issue-download")
+	dir := FS.StringLong("dir", ".", "target directory")
+	only := FS.StringLong("only", "", "download only the attachments with name matching this glob")
+	return &ff.Command{Name: "download", Usage: "download [--dir=.] [--only=*.log] <issueID>", Flags: FS,
+		ShortHelp: "download the attachments of the issue, printing the file names",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			if *only != "" {
+				if _, err := path.Match(*only, ""); err != nil {
+					return fmt.Errorf("--only=%q: %w", *only, err)
+				}
+			}
+			issue, err := cl.IssueGet(ctx, issueID)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(*dir, 0755); err != nil {
+				return err
+			}
+			for _, att := range issue.Attachments {
+				if ok, _ := path.Match(*only, att.FileName); *only != "" && !ok {
+					continue
+				}
+				fn, err := downloadAttachment(ctx, cl, *dir, att)
+				if err != nil {
+					return fmt.Errorf("download %q (%d): %w", att.FileName, att.ID, err)
+				}
+				fmt.Println(fn)
+			}
+			return nil
+		},
+	}
+}
+
+// downloadAttachment writes the attachment into a new file in dir,
+// not overwriting any existing file, and checks its size.
+func downloadAttachment(ctx context.Context, cl *mantis.Client, dir string, att mantis.AttachmentData) (string, error) {
+	fh, err := createUnique(dir, att.FileName)
+	if err != nil {
+		return "", err
+	}
+	fn := fh.Name()
+	rc, err := cl.IssueAttachmentGet(ctx, att.ID)
+	if err == nil {
+		var n int64
+		n, err = io.Copy(fh, rc)
+		rc.Close()
+		if err == nil && n != int64(att.Size) {
+			err = fmt.Errorf("got %d bytes, wanted %d", n, att.Size)
+		}
+	}
+	if closeErr := fh.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(fn)
+		return "", err
+	}
+	return fn, nil
+}
+
+// createUnique creates a new file in dir named as name, or name-1, name-2... if that exists.
+func createUnique(dir, name string) (*os.File, error) {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, "\\", "/")))
+	if name == "/" || name == "." {
+		name = "attachment"
+	}
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 0; ; i++ {
+		fn := filepath.Join(dir, name)
+		if i != 0 {
+			fn = filepath.Join(dir, base+"-"+strconv.Itoa(i)+ext)
+		}
+		fh, err := os.OpenFile(fn, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !errors.Is(err, fs.ErrExist) {
+			return fh, err
+		}
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
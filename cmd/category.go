@@ -0,0 +1,149 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func categoriesCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-categories")
+	projectName := FS.StringLong("project", "", "project name or ID (required)")
+
+	listCmd := &ff.Command{Name: "list", Usage: "list --project=P",
+		Flags:     ff.NewFlagSet("project-categories-list").SetParent(FS),
+		ShortHelp: "list the categories of the project, one per line",
+		Exec: func(ctx context.Context, args []string) error {
+			p, err := resolveProject(ctx, cl, *projectName)
+			if err != nil {
+				return err
+			}
+			resp, err := cl.GetCategoriesForProject(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			for _, c := range resp.Categories {
+				fmt.Println(c)
+			}
+			return nil
+		},
+	}
+
+	addCmd := &ff.Command{Name: "add", Usage: "add --project=P <name>...",
+		Flags:     ff.NewFlagSet("project-categories-add").SetParent(FS),
+		ShortHelp: "add categories to the project",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("category name is required")
+			}
+			p, err := resolveProject(ctx, cl, *projectName)
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if _, err := cl.ProjectCategoryAdd(ctx, p.ID, name); err != nil {
+					return fmt.Errorf("add category %q: %w", name, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	deleteCmd := &ff.Command{Name: "delete", Usage: "delete --project=P <name>...",
+		Flags:     ff.NewFlagSet("project-categories-delete").SetParent(FS),
+		ShortHelp: "delete categories of the project",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("category name is required")
+			}
+			p, err := resolveProject(ctx, cl, *projectName)
+			if err != nil {
+				return err
+			}
+			for _, name := range args {
+				if err := cl.ProjectCategoryDelete(ctx, p.ID, name); err != nil {
+					return fmt.Errorf("delete category %q: %w", name, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	renameFS := ff.NewFlagSet("project-categories-rename").SetParent(FS)
+	assignTo := renameFS.StringLong("assign-to", "", "username of the category's default handler")
+	renameCmd := &ff.Command{Name: "rename", Usage: "rename --project=P [--assign-to=U] <name> <new name>",
+		Flags:     renameFS,
+		ShortHelp: "rename a category of the project",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("the old and the new name is required")
+			}
+			p, err := resolveProject(ctx, cl, *projectName)
+			if err != nil {
+				return err
+			}
+			var userID int
+			if *assignTo != "" {
+				u, err := resolveUser(ctx, cl, p.ID, *assignTo)
+				if err != nil {
+					return err
+				}
+				userID = u.ID
+			}
+			return cl.ProjectCategoryRename(ctx, p.ID, args[0], args[1], userID)
+		},
+	}
+
+	syncFS := ff.NewFlagSet("project-categories-sync").SetParent(FS)
+	from := syncFS.StringLong("from", "", "copy the categories of this project, too")
+	syncCmd := &ff.Command{Name: "sync", Usage: "sync --project=P [--from=Q] [name...]",
+		Flags:     syncFS,
+		ShortHelp: "make the project's categories match the given ones (and the --from project's)",
+		Exec: func(ctx context.Context, args []string) error {
+			p, err := resolveProject(ctx, cl, *projectName)
+			if err != nil {
+				return err
+			}
+			categories := args
+			if *from != "" {
+				q, err := resolveProject(ctx, cl, *from)
+				if err != nil {
+					return err
+				}
+				resp, err := cl.GetCategoriesForProject(ctx, q.ID)
+				if err != nil {
+					return err
+				}
+				categories = append(categories, resp.Categories...)
+			}
+			if len(categories) == 0 {
+				return fmt.Errorf("category names or --from is required")
+			}
+			added, deleted, skipped, err := cl.ProjectCategorySync(ctx, p.ID, categories)
+			for _, name := range added {
+				fmt.Printf("+ %s\n", name)
+			}
+			for _, name := range deleted {
+				fmt.Printf("- %s\n", name)
+			}
+			for _, name := range skipped {
+				fmt.Printf("= %s (inherited, not deleted)\n", name)
+			}
+			return err
+		},
+	}
+
+	return &ff.Command{Name: "categories", Usage: "categories list|add|delete|rename|sync --project=P",
+		Flags:       FS,
+		ShortHelp:   "manage the categories of a project",
+		Subcommands: []*ff.Command{listCmd, addCmd, deleteCmd, renameCmd, syncCmd},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
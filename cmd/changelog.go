@@ -0,0 +1,90 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/changelog"
+)
+
+func changelogCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-changelog")
+	versions := FS.StringListLong("version", "version name (repeatable; default: all versions with resolved issues)")
+	format := FS.StringLong("format", "markdown", "output format: markdown, html or json")
+	groupBy := FS.StringLong("group-by", "category", "group the issues by category or severity")
+	return &ff.Command{Name: "changelog", Usage: "changelog [flags] <project name or ID>", Flags: FS,
+		ShortHelp: "print the resolved issues per fixed-in version",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project is required")
+			}
+			switch *format {
+			case "markdown", "html", "json":
+			default:
+				return fmt.Errorf("--format=%q: unknown format", *format)
+			}
+			p, err := resolveProject(ctx, cl, args[0])
+			if err != nil {
+				return err
+			}
+			c, err := changelog.New(ctx, *cl, p.ID, changelog.Options{Versions: *versions, GroupBy: *groupBy})
+			if err != nil {
+				return err
+			}
+			c.Title = p.Name
+			switch *format {
+			case "html":
+				return c.WriteHTML(os.Stdout)
+			case "json":
+				return E(c)
+			default:
+				return c.WriteMarkdown(os.Stdout)
+			}
+		},
+	}
+}
+
+func roadmapCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-roadmap")
+	versions := FS.StringListLong("version", "unreleased version name (repeatable; default: all)")
+	format := FS.StringLong("format", "table", "output format: table, markdown or json")
+	return &ff.Command{Name: "roadmap", Usage: "roadmap [flags] <project name or ID>", Flags: FS,
+		ShortHelp: "print the issues and the progress per unreleased target version",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project is required")
+			}
+			switch *format {
+			case "table", "markdown", "json":
+			default:
+				return fmt.Errorf("--format=%q: unknown format", *format)
+			}
+			p, err := resolveProject(ctx, cl, args[0])
+			if err != nil {
+				return err
+			}
+			r, err := changelog.NewRoadmap(ctx, *cl, p.ID, changelog.Options{Versions: *versions})
+			if err != nil {
+				return err
+			}
+			r.Title = p.Name
+			switch *format {
+			case "markdown":
+				return r.WriteMarkdown(os.Stdout)
+			case "json":
+				return E(r)
+			default:
+				return r.WriteTable(os.Stdout)
+			}
+		},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,39 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func enumCmd(cl *mantis.Client) *ff.Command {
+	return &ff.Command{Name: "enum", Usage: "enum <status|priority|severity|reproducibility|resolution|projection|eta|view_state|access_levels|project_status|project_view_state|custom_field_type>",
+		ShortHelp: "list the values of an enumeration as JSON Lines",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("enumeration name is required")
+			}
+			values, err := cl.Enums().Get(ctx, mantis.Enum(args[0]))
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			for _, v := range values {
+				if err := enc.Encode(v); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
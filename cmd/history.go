@@ -0,0 +1,81 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func historyCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("issue-history")
+	asJSON := FS.BoolLongDefault("json", false, "print JSON Lines instead of a table")
+	return &ff.Command{Name: "history", Usage: "history [--json] <issueID>", Flags: FS,
+		ShortHelp: "print the timeline of the issue: changes, notes and attachments",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			timeline, err := cl.IssueTimeline(ctx, issueID)
+			if err != nil {
+				return err
+			}
+			if *asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				for _, e := range timeline {
+					if err := enc.Encode(e); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			// The history contains the raw IDs of the enum fields.
+			enumFields := map[string]mantis.Enum{
+				"status": mantis.EnumStatus, "priority": mantis.EnumPriority,
+				"severity": mantis.EnumSeverity, "reproducibility": mantis.EnumReproducibility,
+				"resolution": mantis.EnumResolution, "projection": mantis.EnumProjection,
+				"eta": mantis.EnumETA, "view_state": mantis.EnumViewState,
+			}
+			enumName := func(enum mantis.Enum, s string) string {
+				id, err := strconv.Atoi(s)
+				if err != nil {
+					return s
+				}
+				name, err := cl.Enums().Name(ctx, enum, id)
+				if err != nil {
+					logger.Debug("enum name", "enum", enum, "id", id, "error", err)
+					return s
+				}
+				return name
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			for _, e := range timeline {
+				if h := e.History; h != nil && h.Type == mantis.HistoryFieldChanged {
+					if enum, ok := enumFields[h.Field]; ok {
+						h2 := *h
+						h2.OldValue, h2.NewValue = enumName(enum, h.OldValue), enumName(enum, h.NewValue)
+						e.History = &h2
+					}
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", e.Time.Format("2006-01-02 15:04:05"), e.User, e)
+			}
+			return tw.Flush()
+		},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
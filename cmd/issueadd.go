@@ -0,0 +1,172 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/zRedShift/mimemagic"
+)
+
+func issueAddCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("issue-add")
+	project := FS.StringLong("project", "", "project name or ID (required)")
+	category := FS.StringLong("category", "", "category")
+	summary := FS.StringLong("summary", "", "summary (default: the arguments)")
+	description := FS.StringLong("description", "", "description")
+	descriptionFile := FS.StringLong("description-file", "", `read the description from this file ("-" for stdin); without --description, $EDITOR is started`)
+	priority := FS.StringLong("priority", "", "priority name or ID")
+	severity := FS.StringLong("severity", "", "severity name or ID")
+	reproducibility := FS.StringLong("reproducibility", "", "reproducibility name or ID")
+	handler := FS.StringLong("handler", "", "handler's username")
+	targetVersion := FS.StringLong("target-version", "", "target version")
+	tags := FS.StringListLong("tag", "tag (repeatable; missing tags are created)")
+	fields := FS.StringListLong("field", "custom field as name=value (repeatable; dates as YYYY-MM-DD, multiple values separated by commas)")
+	attach := FS.StringListLong("attach", "file to attach (repeatable)")
+	return &ff.Command{Name: "add", Usage: "add --project=P --summary=S [flags] | add --project=P summary...", Flags: FS,
+		ShortHelp: "create a new issue, printing its ID",
+		Exec: func(ctx context.Context, args []string) error {
+			p, err := resolveProject(ctx, cl, *project)
+			if err != nil {
+				return err
+			}
+			issue := mantis.IssueData{Project: &mantis.ObjectRef{ID: p.ID, Name: p.Name}}
+			if s := strings.TrimSpace(*summary); s != "" {
+				issue.Summary = &s
+			} else if s = strings.TrimSpace(strings.Join(args, " ")); s != "" {
+				issue.Summary = &s
+			} else {
+				return fmt.Errorf("summary is required")
+			}
+			desc := *description
+			if desc == "" {
+				if desc, err = readText(*descriptionFile, ""); err != nil {
+					return fmt.Errorf("read description: %w", err)
+				}
+			}
+			if desc = strings.TrimSpace(desc); desc == "" {
+				return fmt.Errorf("description is required")
+			}
+			issue.Description = &desc
+			for _, x := range []struct {
+				dst  **string
+				name string
+			}{{&issue.Category, *category}, {&issue.TargetVersion, *targetVersion}} {
+				if x.name != "" {
+					s := x.name
+					*x.dst = &s
+				}
+			}
+			for _, x := range []struct {
+				dst  **mantis.ObjectRef
+				enum mantis.Enum
+				name string
+			}{
+				{&issue.Priority, mantis.EnumPriority, *priority},
+				{&issue.Severity, mantis.EnumSeverity, *severity},
+				{&issue.Reproducibility, mantis.EnumReproducibility, *reproducibility},
+			} {
+				if x.name != "" {
+					ref, err := cl.Enums().Ref(ctx, x.enum, x.name)
+					if err != nil {
+						return err
+					}
+					*x.dst = &ref
+				}
+			}
+			if *handler != "" {
+				u, err := resolveUser(ctx, cl, p.ID, *handler)
+				if err != nil {
+					return err
+				}
+				issue.Handler = &u
+			}
+			if len(*fields) != 0 {
+				defs, err := cl.ProjectGetCustomFields(ctx, p.ID)
+				if err != nil {
+					return err
+				}
+				for _, f := range *fields {
+					name, value, ok := strings.Cut(f, "=")
+					if !ok || name == "" {
+						return fmt.Errorf("--field=%q: name=value is required", f)
+					}
+					for _, d := range defs {
+						if strings.EqualFold(d.Field.Name, name) {
+							value = customFieldValue(d, value)
+							break
+						}
+					}
+					issue.SetCustomField(name, value)
+				}
+			}
+			for _, fn := range *attach {
+				if _, err := os.Stat(fn); err != nil {
+					return err
+				}
+			}
+
+			issueID, err := cl.IssueAdd(ctx, issue)
+			if err != nil {
+				return err
+			}
+			fmt.Println(issueID)
+			if len(*tags) != 0 {
+				if _, err := cl.IssueEditTags(ctx, issueID, *tags, nil); err != nil {
+					return fmt.Errorf("tag issue %d: %w", issueID, err)
+				}
+			}
+			for _, fn := range *attach {
+				if _, err := uploadFile(ctx, cl, issueID, fn); err != nil {
+					return fmt.Errorf("attach %q to issue %d: %w", fn, issueID, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// customFieldValue converts the date (YYYY-MM-DD) and the comma-separated multiple values
+// to the format Mantis expects for the field.
+func customFieldValue(def mantis.CustomFieldDefinitionData, value string) string {
+	switch def.Type {
+	case mantis.CustomFieldDate:
+		if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+			return mantis.FormatCustomFieldTime(t)
+		}
+	case mantis.CustomFieldCheckbox, mantis.CustomFieldMultiList:
+		if !strings.HasPrefix(value, "|") {
+			return mantis.FormatCustomFieldList(strings.Split(value, ","))
+		}
+	}
+	return value
+}
+
+// uploadFile attaches the file to the issue, with the detected content type.
+func uploadFile(ctx context.Context, cl *mantis.Client, issueID int, fn string) (int, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return 0, err
+	}
+	defer fh.Close()
+
+	t, err := mimemagic.MatchFile(fh)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = fh.Seek(0, 0); err != nil {
+		return 0, err
+	}
+	return cl.IssueAttachmentAdd(ctx, issueID, filepath.Base(fn), t.MediaType(), fh)
+}
+
+// vim: set fileencoding=utf-8 noet:
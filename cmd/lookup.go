@@ -0,0 +1,104 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+// resolveProject finds the accessible project (or subproject) by its name or ID.
+func resolveProject(ctx context.Context, cl *mantis.Client, nameOrID string) (mantis.ProjectData, error) {
+	if nameOrID == "" {
+		return mantis.ProjectData{}, fmt.Errorf("project is required")
+	}
+	projects, err := cl.ProjectsGetUserAccessible(ctx)
+	if err != nil {
+		return mantis.ProjectData{}, err
+	}
+	id, _ := strconv.Atoi(nameOrID)
+	var find func([]mantis.ProjectData) (mantis.ProjectData, bool)
+	find = func(pp []mantis.ProjectData) (mantis.ProjectData, bool) {
+		for _, p := range pp {
+			if (id != 0 && p.ID == id) || strings.EqualFold(p.Name, nameOrID) {
+				return p, true
+			}
+			if p, ok := find(p.Subprojects); ok {
+				return p, true
+			}
+		}
+		return mantis.ProjectData{}, false
+	}
+	if p, ok := find(projects); ok {
+		return p, nil
+	}
+	return mantis.ProjectData{}, fmt.Errorf("project %q: %w", nameOrID, mantis.ErrProjectNotFound)
+}
+
+// resolveUser finds the user of the project by name.
+func resolveUser(ctx context.Context, cl *mantis.Client, projectID int, name string) (mantis.AccountData, error) {
+	users, err := cl.ProjectGetUsers(ctx, projectID, 0)
+	if err != nil {
+		return mantis.AccountData{}, err
+	}
+	for _, u := range users {
+		if u.Name == name {
+			return u, nil
+		}
+	}
+	return mantis.AccountData{}, fmt.Errorf("user %q not found in project %d", name, projectID)
+}
+
+// readText returns the text from the file ("-" is stdin),
+// or edited in $EDITOR, starting with initial, if fn is empty.
+func readText(fn, initial string) (string, error) {
+	switch fn {
+	case "":
+		return editText(initial)
+	case "-":
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	default:
+		b, err := os.ReadFile(fn)
+		return string(b), err
+	}
+}
+
+// editText lets the user edit the text in $EDITOR (or vi), returning the result.
+func editText(initial string) (string, error) {
+	fh, err := os.CreateTemp("", "mantiscli-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(fh.Name())
+	if _, err = fh.WriteString(initial); err == nil {
+		err = fh.Close()
+	}
+	if err != nil {
+		fh.Close()
+		return "", err
+	}
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	// $EDITOR may contain arguments, too.
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", fh.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s: %w", editor, fh.Name(), err)
+	}
+	b, err := os.ReadFile(fh.Name())
+	return string(b), err
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -2,15 +2,6 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
-//go:generate go get github.com/hooklift/gowsdl/cmd/gowsdl
-//go:generate wget -O mantis.wsdl.raw -q "https://www.unosoft.hu/mantis/kobe/api/soap/mantisconnect.php?wsdl"
-//go:generate iconv -f ISO-8859-2 -t UTF-8 mantis.wsdl.raw -o mantis.wsdl
-//go:generate sh -c "sed -i -e '1{s/ISO-8859-1/UTF-8/}' mantis.wsdl"
-//go:generate rm -f mantis.wsdl.raw
-//go:generate mkdir -p mantisconnect
-//go:generate mv mantis.wsdl mantisconnect/
-//go:generate gowsdl -o mantisconnect.go -p mantisconnect mantisconnect/mantis.wsdl
-
 package main
 
 import (
@@ -18,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 
@@ -53,6 +45,7 @@ func Main() error {
 	username := FS.String('u', "user", os.Getenv("USER"), "Mantis user name")
 	passwordEnv := FS.StringLong("password-env", "MC_PASSWORD", "Environment variable's name for the password")
 	configFile := FS.String('c', "config", os.ExpandEnv("/home/$USER/.config/mantiscli.json"), "config file with the stored password")
+	maxAttachmentSize := FS.IntLong("max-attachment-size", 0, "maximum size of the uploaded attachments, in bytes (0: unlimited)")
 
 	if err := app.Parse(os.Args[1:]); err != nil {
 		ffhelp.Command(app).WriteTo(os.Stderr)
@@ -92,11 +85,16 @@ func Main() error {
 		}
 		fmt.Printf("\n")
 	}
+	// Never log the password, not even in verbose mode.
+	logger = slog.New(mantis.NewRedactHandler(logger.Handler(), passw))
+	ctx = zlog.NewSContext(ctx, logger)
+
 	var err error
 	if cl, err = mantis.New(ctx, u, *username, passw); err != nil {
 		cancel()
 		return err
 	}
+	cl.MaxAttachmentSize = int64(*maxAttachmentSize)
 	if verbose > 0 {
 		cl.Logger = logger.WithGroup("mantis-soap")
 		mantis.SetLogger(cl.Logger)
@@ -109,7 +107,7 @@ func Main() error {
 			logger.Error("create", "error", err)
 		} else {
 			if err = json.NewEncoder(fh).Encode(conf); err != nil {
-				logger.Error("encode", "config", conf, "error", err)
+				logger.Error("encode", "error", err)
 			} else if closeErr := fh.Close(); closeErr != nil {
 				logger.Error("close", "error", err)
 			}
@@ -10,15 +10,13 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"path/filepath"
-	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/peterbourgon/ff/v4"
 	"github.com/tgulacsi/mantis-soap"
 	"github.com/titanous/json5"
-	"github.com/zRedShift/mimemagic"
 )
 
 var logger = slog.Default()
@@ -44,35 +42,84 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 			return E(answer)
 		},
 	}
-	getIssuesCmd := &ff.Command{Name: "get", Usage: "get",
+	getIssuesCmd := &ff.Command{Name: "get", Usage: "get <issueIDs...>",
+		ShortHelp: "get the issues, printing the found ones even if some fail",
 		Exec: func(ctx context.Context, args []string) error {
 			issueIDs, err := toInts(args)
 			if err != nil {
 				return err
 			}
-			answer := make(map[string]interface{}, len(issueIDs))
-			for _, i := range issueIDs {
-				issue, err := cl.IssueGet(ctx, i)
-				if err != nil {
-					return err
-				}
-				answer[strconv.Itoa(i)] = issue
+			issues, getErr := cl.IssuesGet(ctx, issueIDs)
+			answer := make(map[string]interface{}, len(issues))
+			for _, issue := range issues {
+				answer[strconv.Itoa(int(*issue.ID))] = issue
 			}
-			return E(answer)
+			if err := E(answer); err != nil {
+				return err
+			}
+			if getErr != nil {
+				return fmt.Errorf("get %d issues: %w", len(issueIDs), getErr)
+			}
+			return nil
 		},
 	}
-	searchIssuesCmd := &ff.Command{Name: "search", Usage: "search",
+	FS := ff.NewFlagSet("issue-search")
+	searchPerPage := FS.IntLong("per-page", mantis.DefaultPerPage, "page size of the queries")
+	searchStatus := FS.StringListLong("status", "status name or ID (repeatable)")
+	searchPriority := FS.StringListLong("priority", "priority name or ID (repeatable)")
+	searchSeverity := FS.StringListLong("severity", "severity name or ID (repeatable)")
+	searchFull := FS.BoolLongDefault("full", false, "print the full issues, not just the headers")
+	searchIssuesCmd := &ff.Command{Name: "search", Usage: "search [flags] [filter as JSON5]", Flags: FS,
+		ShortHelp: "search issues, printing their headers as JSON Lines",
 		Exec: func(ctx context.Context, args []string) error {
 			var filter mantis.FilterSearchData
-			if err := json5.Unmarshal([]byte(strings.Join(args, " ")), &filter); err != nil {
-				return fmt.Errorf("unmarshal %q as %#v: %w", args, filter, err)
+			if len(args) != 0 {
+				if err := json5.Unmarshal([]byte(strings.Join(args, " ")), &filter); err != nil {
+					return fmt.Errorf("unmarshal %q as %#v: %w", args, filter, err)
+				}
 			}
-			ids, err := cl.FilterSearchIssueIDs(ctx, filter, 0, 1000)
-			if err != nil {
-				return err
+			for _, x := range []struct {
+				dst   *[]int
+				enum  mantis.Enum
+				names []string
+			}{
+				{&filter.StatusID, mantis.EnumStatus, *searchStatus},
+				{&filter.PriorityID, mantis.EnumPriority, *searchPriority},
+				{&filter.SeverityID, mantis.EnumSeverity, *searchSeverity},
+			} {
+				for _, name := range x.names {
+					id, err := cl.Enums().ID(ctx, x.enum, name)
+					if err != nil {
+						return err
+					}
+					*x.dst = append(*x.dst, id)
+				}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			if !*searchFull {
+				for header, err := range cl.AllFilterSearchIssueHeaders(ctx, filter, *searchPerPage) {
+					if err != nil {
+						return err
+					}
+					if err := enc.Encode(header); err != nil {
+						return err
+					}
+				}
+				return nil
 			}
-			slices.Sort(ids)
-			return E(ids)
+			for id, err := range cl.AllFilterSearchIssueIDs(ctx, filter, *searchPerPage) {
+				if err != nil {
+					return err
+				}
+				issue, err := cl.IssueGet(ctx, id)
+				if err != nil {
+					return err
+				}
+				if err := enc.Encode(issue); err != nil {
+					return err
+				}
+			}
+			return nil
 		},
 	}
 
@@ -112,20 +159,7 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 					return nil
 				}
 			}
-			fh, err := os.Open(fn)
-			if err != nil {
-				return err
-			}
-			defer fh.Close()
-
-			t, err := mimemagic.MatchFile(fh)
-			if err != nil {
-				return err
-			}
-			if _, err = fh.Seek(0, 0); err != nil {
-				return err
-			}
-			if _, err := cl.IssueAttachmentAdd(ctx, issueID, filepath.Base(fn), t.MediaType(), fh); err != nil {
+			if _, err := uploadFile(ctx, cl, issueID, fn); err != nil {
 				return fmt.Errorf("add attachment %q: %w", fn, err)
 			}
 			return nil
@@ -145,24 +179,7 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 			return E(issue.Attachments)
 		},
 	}
-	issueDownloadAttachmentCmd := &ff.Command{Name: "download", Usage: "download attachments of the issue",
-		Exec: func(ctx context.Context, args []string) error {
-			issueID, err := strconv.Atoi(args[0])
-			if err != nil {
-				return err
-			}
-
-			issue, err := cl.IssueGet(ctx, issueID)
-			if err != nil {
-				return err
-			}
-			for _, att := range issue.Attachments {
-				return E(att.DownloadURL)
-			}
-
-			return nil
-		},
-	}
+	issueDownloadAttachmentCmd := downloadCmd(cl)
 
 	attachmentAddCmd := ff.Command{Name: "add", ShortHelp: "add attachment",
 		Exec: addAttachmentCmd.Exec,
@@ -173,6 +190,7 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 	}
 
 	attachmentDownloadCmd := ff.Command{Name: "download", ShortHelp: "download attachments",
+		Usage: issueDownloadAttachmentCmd.Usage, Flags: issueDownloadAttachmentCmd.Flags,
 		Exec: issueDownloadAttachmentCmd.Exec,
 	}
 
@@ -190,72 +208,14 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 			return addMonitors(ctx, cl, issueID, args[1:])
 		},
 	}
-	statusCmd := ff.Command{Name: "status", ShortHelp: "set issue's status",
-		Exec: func(ctx context.Context, args []string) error {
-			status, err := strconv.Atoi(args[0])
-			if err != nil {
-				return err
-			}
-			issueIDs, err := toInts(args[1:])
-			if err != nil {
-				return err
-			}
-			for _, issueID := range issueIDs {
-				issue, err := cl.IssueGet(ctx, issueID)
-				if err != nil {
-					return err
-				}
-				if issue.Status.ID >= status {
-					fmt.Printf("SKIP %d (%d=%q)\n", issueID, issue.Status.ID, issue.Status.Name)
-					continue
-				}
-				issue.Status.ID, issue.Status.Name = status, ""
-				custFields := make([]mantis.CustomFieldData, 0, len(issue.CustomFields))
-				for _, f := range issue.CustomFields {
-					if f.Value != "" {
-						custFields = append(custFields, f)
-					}
-				}
-				issue.CustomFields = custFields
-				if _, err = cl.IssueUpdate(ctx, issueID, issue); err != nil {
-					return err
-				}
-			}
-			return nil
-		},
-	}
-
 	issueCmd := &ff.Command{Name: "issue", Usage: "do sth on issues",
 		Subcommands: []*ff.Command{
-			existCmd, getIssuesCmd, searchIssuesCmd,
+			issueAddCmd(cl), existCmd, getIssuesCmd, searchIssuesCmd,
 			getMonitorsCmd, addMonitorsCmd,
 			addAttachmentCmd, issueListAttachmentsCmd, issueDownloadAttachmentCmd,
-			&statusCmd,
-		},
-	}
-
-	addNoteCmd := &ff.Command{
-		Name: "add", Usage: "add a note to an issue",
-		Exec: func(ctx context.Context, args []string) error {
-			issueID, err := strconv.Atoi(args[0])
-			if err != nil {
-				return err
-			}
-			args = args[1:]
-			noteID, err := cl.IssueNoteAdd(ctx, issueID, mantis.IssueNoteData{
-				Reporter: cl.User,
-				Text:     strings.Join(args, " "),
-			})
-			if err != nil {
-				return err
-			}
-			logger.Info("added", "note", noteID)
-			return nil
+			statusCmd(cl), relationsCmd(cl), historyCmd(cl), issueTagCmd(cl),
 		},
 	}
-	noteCmd := &ff.Command{Name: "note", Usage: "do sth with notes",
-		Subcommands: []*ff.Command{addNoteCmd},
-	}
 
 	listProjectsCmd := &ff.Command{Name: "list", Usage: "list projects",
 		Exec: func(ctx context.Context, args []string) error {
@@ -282,6 +242,43 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 		},
 	}
 
+	FS = ff.NewFlagSet("project-issues")
+	projectIssuesPerPage := FS.IntLong("per-page", mantis.DefaultPerPage, "page size of the queries")
+	projectIssuesFull := FS.BoolLongDefault("full", false, "print the full issues, not just the headers")
+	projectIssuesCmd := &ff.Command{Name: "issues", Usage: "issues [flags] <projectID>", Flags: FS,
+		ShortHelp: "list the issue headers of the project as JSON Lines",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("projectID is required")
+			}
+			projectID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			if !*projectIssuesFull {
+				for header, err := range cl.AllProjectIssueHeaders(ctx, projectID, *projectIssuesPerPage) {
+					if err != nil {
+						return err
+					}
+					if err := enc.Encode(header); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			for issue, err := range cl.AllProjectIssues(ctx, projectID, *projectIssuesPerPage) {
+				if err != nil {
+					return err
+				}
+				if err := enc.Encode(issue); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
 	var projectID int
 	pVersionsListCmd := &ff.Command{Name: "list", Usage: "list project versions <projectID>",
 		Exec: func(ctx context.Context, args []string) error {
@@ -296,7 +293,7 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 		},
 	}
 
-	FS := ff.NewFlagSet("project-version-add")
+	FS = ff.NewFlagSet("project-version-add")
 	pVersionsAddDescription := FS.StringLong("description", "", "version description")
 	pVersionsAddReleased := FS.BoolLongDefault("released", false, "released?")
 	pVersionsAddObsolete := FS.BoolLongDefault("obsolete", false, "obsolete?")
@@ -403,12 +400,17 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 	FS = ff.NewFlagSet("projects")
 	FS.IntVar(&projectID, 0, "project", 0, "project id")
 	projectVersionsCmd := &ff.Command{Name: "versions", Usage: "do sth with versions",
-		Flags:       FS,
-		Subcommands: []*ff.Command{pVersionsListCmd, pVersionsAddCmd, pVersionsDeleteCmd, pVersionsUpdateCmd},
+		Flags: FS,
+		Subcommands: []*ff.Command{pVersionsListCmd, pVersionsAddCmd, pVersionsDeleteCmd, pVersionsUpdateCmd,
+			projectVersionReleaseCmd(cl, FS, &projectID)},
 	}
 
 	projectsCmd := &ff.Command{Name: "project", Usage: "do sth with projects",
-		Subcommands: []*ff.Command{listProjectsCmd, projectVersionsCmd},
+		Subcommands: []*ff.Command{
+			listProjectsCmd, projectIssuesCmd, projectVersionsCmd,
+			projectAddCmd(cl), projectUpdateCmd(cl), projectDeleteCmd(cl), projectTreeCmd(cl),
+			categoriesCmd(cl), projectFieldsCmd(cl), changelogCmd(cl), roadmapCmd(cl),
+		},
 	}
 
 	FS = ff.NewFlagSet("project-list-users")
@@ -460,7 +462,7 @@ func App(cl *mantis.Client) (*ff.Command, *ff.FlagSet) {
 		Usage: "Mantis Command-Line Interface",
 		Subcommands: []*ff.Command{
 			&attachmentCmd,
-			issueCmd, noteCmd, projectsCmd, usersCmd},
+			enumCmd(cl), issueCmd, noteCmd(cl), projectsCmd, reportCmd(cl), tagCmd(cl), usersCmd},
 	}, FS
 }
 
@@ -530,7 +532,15 @@ func addMonitors(ctx context.Context, cl *mantis.Client, issueID int, plusMonito
 	if n == 0 || err != nil {
 		return err
 	}
-	issue.CustomFields, issue.Attachments, issue.Notes = nil, nil, nil
-	_, err = cl.IssueUpdate(ctx, issueID, issue)
-	return err
+	return cl.IssuePatch(ctx, issueID, mantis.IssuePatch{
+		Monitors: issue.Monitors, IfUnmodifiedSince: lastUpdated(issue),
+	})
+}
+
+// lastUpdated returns the LastUpdated of the issue, or the zero time.
+func lastUpdated(issue mantis.IssueData) time.Time {
+	if issue.LastUpdated == nil {
+		return time.Time{}
+	}
+	return time.Time(*issue.LastUpdated)
 }
@@ -0,0 +1,509 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command mantisgen generates the request and response types and the Client methods
+// for the operations of mantisconnect.wsdl which are not implemented by hand.
+//
+// It reads the WSDL and the Go files of the output's package (except the output itself),
+// skips the operations which already have an XMLName-tagged type there,
+// and writes XMLName-tagged types and thin Client methods for the rest,
+// with the complex types they need. As in the hand-written methods, the boolean results
+// are dropped: those methods return only an error.
+//
+//	go run ./cmd/mantisgen -o generated.go mantisconnect.wsdl
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	if err := Main(); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+}
+
+func Main() error {
+	flagOut := flag.String("o", "generated.go", "output file, in the package's directory")
+	flagPkg := flag.String("pkg", "mantis", "package name")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [mantisconnect.wsdl]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	wsdlPath := "mantisconnect.wsdl"
+	if flag.NArg() > 0 {
+		wsdlPath = flag.Arg(0)
+	}
+	b, err := generate(wsdlPath, *flagOut, *flagPkg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*flagOut, b, 0644)
+}
+
+// generate returns the formatted source of the output file.
+func generate(wsdlPath, outPath, pkg string) ([]byte, error) {
+	defs, err := readWSDL(wsdlPath)
+	if err != nil {
+		return nil, err
+	}
+	decl, err := readPackage(filepath.Dir(outPath), filepath.Base(outPath))
+	if err != nil {
+		return nil, err
+	}
+	g := generator{defs: defs, decl: decl, types: make(map[string]bool)}
+	for _, op := range defs.operations() {
+		if decl.ops[op.Name] {
+			continue
+		}
+		if err := g.operation(op); err != nil {
+			return nil, fmt.Errorf("%s: %w", op.Name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mantisgen from %s. DO NOT EDIT.\n\npackage %s\n\n",
+		filepath.Base(wsdlPath), pkg)
+	if g.methods.Len() != 0 {
+		buf.WriteString("import (\n\t\"context\"\n\t\"encoding/xml\"\n)\n\n")
+	}
+	buf.Write(g.structs.Bytes())
+	buf.Write(g.methods.Bytes())
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return buf.Bytes(), fmt.Errorf("format: %w", err)
+	}
+	return b, nil
+}
+
+// nsMantis is the namespace of the operations.
+const nsMantis = "http://futureware.biz/mantisconnect"
+
+type definitions struct {
+	ComplexTypes []complexType `xml:"types>schema>complexType"`
+	Messages     []message     `xml:"message"`
+	PortTypes    []struct {
+		Operations []operation `xml:"operation"`
+	} `xml:"portType"`
+}
+
+type complexType struct {
+	Name        string    `xml:"name,attr"`
+	Elements    []element `xml:"all>element"`
+	Restriction struct {
+		Attribute struct {
+			// ArrayType is like "tns:ObjectRef[]" for SOAP-encoded arrays.
+			ArrayType string `xml:"http://schemas.xmlsoap.org/wsdl/ arrayType,attr"`
+		} `xml:"attribute"`
+	} `xml:"complexContent>restriction"`
+}
+
+type element struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type message struct {
+	Name  string    `xml:"name,attr"`
+	Parts []element `xml:"part"`
+}
+
+type operation struct {
+	Name          string `xml:"name,attr"`
+	Documentation string `xml:"documentation"`
+	Input         struct {
+		Message string `xml:"message,attr"`
+	} `xml:"input"`
+	Output struct {
+		Message string `xml:"message,attr"`
+	} `xml:"output"`
+}
+
+func readWSDL(path string) (definitions, error) {
+	var defs definitions
+	fh, err := os.Open(path)
+	if err != nil {
+		return defs, err
+	}
+	defer fh.Close()
+	d := xml.NewDecoder(fh)
+	// The bundled WSDL declares ISO-8859-1, but is ASCII.
+	d.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	if err := d.Decode(&defs); err != nil {
+		return defs, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return defs, nil
+}
+
+func (defs definitions) operations() []operation {
+	var ops []operation
+	for _, pt := range defs.PortTypes {
+		ops = append(ops, pt.Operations...)
+	}
+	return ops
+}
+
+func (defs definitions) message(name string) (message, error) {
+	name = strings.TrimPrefix(name, "tns:")
+	for _, m := range defs.Messages {
+		if m.Name == name {
+			return m, nil
+		}
+	}
+	return message{}, fmt.Errorf("no message %q", name)
+}
+
+func (defs definitions) complexType(name string) (complexType, bool) {
+	for _, ct := range defs.ComplexTypes {
+		if ct.Name == name {
+			return ct, true
+		}
+	}
+	return complexType{}, false
+}
+
+// declared is what the hand-written files of the package declare.
+type declared struct {
+	// types are the names of the declared types.
+	types map[string]bool
+	// methods are the names of the methods of Client.
+	methods map[string]bool
+	// ops are the elements of the XMLName tags: the implemented operations.
+	ops map[string]bool
+}
+
+func readPackage(dir, skip string) (declared, error) {
+	decl := declared{types: make(map[string]bool), methods: make(map[string]bool), ops: make(map[string]bool)}
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return decl, err
+	}
+	fset := token.NewFileSet()
+	for _, fn := range files {
+		if base := filepath.Base(fn); base == skip || strings.HasSuffix(base, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, fn, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return decl, err
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv != nil && len(d.Recv.List) == 1 {
+					typ := d.Recv.List[0].Type
+					if star, ok := typ.(*ast.StarExpr); ok {
+						typ = star.X
+					}
+					if id, ok := typ.(*ast.Ident); ok && id.Name == "Client" {
+						decl.methods[d.Name.Name] = true
+					}
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					decl.types[ts.Name.Name] = true
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						if op := xmlNameOp(st); op != "" {
+							decl.ops[op] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	if len(decl.types) == 0 {
+		return decl, fmt.Errorf("no types in %s", dir)
+	}
+	return decl, nil
+}
+
+// xmlNameOp returns the element of the XMLName field in the mantisconnect namespace.
+func xmlNameOp(st *ast.StructType) string {
+	for _, field := range st.Fields.List {
+		if len(field.Names) != 1 || field.Names[0].Name != "XMLName" || field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return ""
+		}
+		name, _, _ := strings.Cut(reflect.StructTag(tag).Get("xml"), ",")
+		if local, ok := strings.CutPrefix(name, nsMantis+" "); ok {
+			return local
+		}
+	}
+	return ""
+}
+
+type generator struct {
+	defs             definitions
+	decl             declared
+	types            map[string]bool
+	structs, methods bytes.Buffer
+}
+
+// param is a non-auth part of a request message.
+type param struct {
+	Name, Field, Type, Value string
+}
+
+func (g *generator) operation(op operation) error {
+	name := goName(strings.TrimPrefix(op.Name, "mc_"))
+	reqName, respName := name+"Request", name+"Response"
+	for _, s := range []string{reqName, respName} {
+		if g.decl.types[s] || g.types[s] {
+			return fmt.Errorf("type %s is already declared", s)
+		}
+	}
+	if g.decl.methods[name] {
+		return fmt.Errorf("method Client.%s is already declared", name)
+	}
+	input, err := g.defs.message(op.Input.Message)
+	if err != nil {
+		return err
+	}
+	output, err := g.defs.message(op.Output.Message)
+	if err != nil {
+		return err
+	}
+
+	// The complex types are written to g.structs while the parts are processed.
+	var buf bytes.Buffer
+	var auth bool
+	var params []param
+	fmt.Fprintf(&buf, "type %s struct {\n\tXMLName xml.Name `xml:\"%s %s\"`\n", reqName, nsMantis, op.Name)
+	for _, p := range input.Parts {
+		if p.Name == "username" || p.Name == "password" {
+			if !auth {
+				auth = true
+				buf.WriteString("\tAuth\n")
+			}
+			continue
+		}
+		typ, tag, err := g.goType(p)
+		if err != nil {
+			return err
+		}
+		prm := param{Name: lowerFirst(goName(p.Name)), Field: goName(p.Name), Type: typ}
+		if token.IsKeyword(prm.Name) {
+			prm.Name += "_"
+		}
+		prm.Value = prm.Name
+		// The issue IDs are IssueID, as in the hand-written requests.
+		if strings.HasSuffix(p.Name, "issue_id") && typ == "int" {
+			prm.Value = "IssueID(" + prm.Name + ")"
+			typ = "IssueID"
+		}
+		params = append(params, prm)
+		fmt.Fprintf(&buf, "\t%s %s `xml:\"%s\"`\n", prm.Field, typ, tag)
+	}
+	buf.WriteString("}\n\n")
+
+	var retType string
+	fmt.Fprintf(&buf, "type %s struct {\n\tXMLName xml.Name `xml:\"%s %sResponse\"`\n", respName, nsMantis, op.Name)
+	switch len(output.Parts) {
+	case 0:
+	case 1:
+		p := output.Parts[0]
+		if p.Name != "return" {
+			return fmt.Errorf("response part %q, wanted return", p.Name)
+		}
+		var tag string
+		if retType, tag, err = g.goType(p); err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "\tReturn %s `xml:\"%s\"`\n", retType, tag)
+	default:
+		return fmt.Errorf("%d response parts", len(output.Parts))
+	}
+	buf.WriteString("}\n\n")
+	g.structs.Write(buf.Bytes())
+	g.types[reqName], g.types[respName] = true, true
+
+	// The boolean results (of the deletes, for example) are dropped, as in the hand-written methods:
+	// Mantis returns true or a fault - false would mean a failure, which is reported as a fault.
+	if retType == "bool" {
+		retType = ""
+	}
+
+	g.methods.WriteString(comment(docComment(name, op), 100))
+	fmt.Fprintf(&g.methods, "func (c Client) %s(ctx context.Context", name)
+	for i, p := range params {
+		// Group the consecutive parameters of the same type.
+		if i+1 < len(params) && params[i+1].Type == p.Type {
+			fmt.Fprintf(&g.methods, ", %s", p.Name)
+		} else {
+			fmt.Fprintf(&g.methods, ", %s %s", p.Name, p.Type)
+		}
+	}
+	fields := make([]string, 0, len(params)+1)
+	if auth {
+		fields = append(fields, "Auth: c.auth")
+	}
+	for _, p := range params {
+		fields = append(fields, p.Field+": "+p.Value)
+	}
+	call := fmt.Sprintf("c.Call(ctx, %q,\n\t\t%s{%s},\n\t\t&resp)", op.Name, reqName, strings.Join(fields, ", "))
+	if retType == "" {
+		fmt.Fprintf(&g.methods, ") error {\n\tvar resp %s\n\treturn %s\n}\n\n", respName, call)
+	} else {
+		fmt.Fprintf(&g.methods, ") (%s, error) {\n\tvar resp %s\n\terr := %s\n\treturn resp.Return, err\n}\n\n",
+			retType, respName, call)
+	}
+	return nil
+}
+
+// goType returns the Go type and the xml tag of the part or element.
+func (g *generator) goType(e element) (typ, tag string, err error) {
+	typ, err = g.typeOf(e.Type)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", e.Name, err)
+	}
+	tag = e.Name
+	if strings.HasPrefix(typ, "[]") {
+		tag += ">item"
+	}
+	return typ, tag, nil
+}
+
+var xsdTypes = map[string]string{
+	"xsd:integer":      "int",
+	"xsd:int":          "int",
+	"xsd:string":       "string",
+	"xsd:anyURI":       "string",
+	"xsd:boolean":      "bool",
+	"xsd:double":       "float64",
+	"xsd:float":        "float64",
+	"xsd:dateTime":     "Time",
+	"xsd:base64Binary": "Base64",
+}
+
+// typeOf returns the Go type of the XSD type, generating the missing complex types.
+func (g *generator) typeOf(xsdType string) (string, error) {
+	if typ, ok := xsdTypes[xsdType]; ok {
+		return typ, nil
+	}
+	name, ok := strings.CutPrefix(xsdType, "tns:")
+	if !ok {
+		return "", fmt.Errorf("unknown type %q", xsdType)
+	}
+	ct, ok := g.defs.complexType(name)
+	if !ok {
+		return "", fmt.Errorf("no complex type %q", name)
+	}
+	if at := ct.Restriction.Attribute.ArrayType; at != "" {
+		elem, err := g.typeOf(strings.TrimSuffix(at, "[]"))
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	}
+	if g.decl.types[name] || g.types[name] {
+		return name, nil
+	}
+	if len(ct.Elements) == 0 {
+		return "", fmt.Errorf("complex type %q has no elements", name)
+	}
+	g.types[name] = true
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "type %s struct {\n", name)
+	for _, e := range ct.Elements {
+		typ, tag, err := g.goType(e)
+		if err != nil {
+			return "", fmt.Errorf("%s.%w", name, err)
+		}
+		// The nested structs and the times are optional.
+		if _, isStruct := g.defs.complexType(strings.TrimPrefix(e.Type, "tns:")); typ == "Time" || isStruct && !strings.HasPrefix(typ, "[]") {
+			typ = "*" + typ
+		}
+		fmt.Fprintf(&buf, "\t%s %s `xml:\"%s,omitempty\"`\n", goName(e.Name), typ, tag)
+	}
+	buf.WriteString("}\n\n")
+	g.structs.Write(buf.Bytes())
+	return name, nil
+}
+
+// docComment returns the doc comment of the method from the documentation of the operation:
+// "Delete the issue." => "IssueDelete deletes the issue."
+func docComment(name string, op operation) string {
+	verb, rest, _ := strings.Cut(strings.Join(strings.Fields(op.Documentation), " "), " ")
+	if verb == "" {
+		return name + " calls " + op.Name + "."
+	}
+	verb = strings.ToLower(verb[:1]) + verb[1:]
+	if !strings.HasSuffix(verb, "s") {
+		verb += "s"
+	}
+	return name + " " + verb + " " + strings.TrimSuffix(rest, ".") + "."
+}
+
+// comment returns the text as a line comment, wrapped at width.
+func comment(text string, width int) string {
+	var buf strings.Builder
+	n := 0
+	for i, w := range strings.Fields(text) {
+		if i != 0 && n+1+len(w) > width {
+			buf.WriteByte('\n')
+			n = 0
+		}
+		if n == 0 {
+			buf.WriteString("//")
+			n = 2
+		}
+		buf.WriteString(" " + w)
+		n += 1 + len(w)
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]string{"id": "ID", "ids": "IDs", "url": "URL", "os": "OS", "eta": "ETA"}
+
+// goName returns the CamelCase form of the snake_case name.
+func goName(s string) string {
+	var buf strings.Builder
+	for _, w := range strings.Split(s, "_") {
+		if up, ok := initialisms[w]; ok {
+			buf.WriteString(up)
+		} else if w != "" {
+			buf.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return buf.String()
+}
+
+// lowerFirst returns the name with its first word in lower case: ProjectID => projectID, IDs => ids.
+func lowerFirst(s string) string {
+	for w, up := range initialisms {
+		if rest, ok := strings.CutPrefix(s, up); ok && (rest == "" || rest[0] >= 'A' && rest[0] <= 'Z') {
+			return w + rest
+		}
+	}
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// vim: set fileencoding=utf-8 noet:
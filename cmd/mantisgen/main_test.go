@@ -0,0 +1,64 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGenerated checks that generated.go is up to date.
+func TestGenerated(t *testing.T) {
+	want, err := os.ReadFile("../../generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generate("../../mantisconnect.wsdl", "../../generated.go", "mantis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("generated.go is stale, run go generate")
+	}
+}
+
+func TestDocComment(t *testing.T) {
+	for _, tC := range []struct {
+		name, doc, want string
+	}{
+		{"Version", "", "Version calls mc_version."},
+		{"IssueDelete", "Delete the issue with the specified id.", "IssueDelete deletes the issue with the specified id."},
+		{"IssueCheckin", "Notifies MantisBT of a check-in", "IssueCheckin notifies MantisBT of a check-in."},
+		{"FilterGet", "Get the filters\n\t\tdefined for the project.", "FilterGet gets the filters defined for the project."},
+	} {
+		op := operation{Name: "mc_" + strings.ToLower(tC.name), Documentation: tC.doc}
+		if got := docComment(tC.name, op); got != tC.want {
+			t.Errorf("%s: got %q, wanted %q", tC.name, got, tC.want)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, tC := range []struct {
+		in, name, param string
+	}{
+		{"issue_get_biggest_id", "IssueGetBiggestID", "issueGetBiggestID"},
+		{"project_id", "ProjectID", "projectID"},
+		{"issue_ids", "IssueIDs", "issueIDs"},
+		{"ids", "IDs", "ids"},
+		{"url", "URL", "url"},
+		{"os_build", "OSBuild", "osBuild"},
+		{"per_page", "PerPage", "perPage"},
+	} {
+		if got := goName(tC.in); got != tC.name {
+			t.Errorf("goName(%q): got %q, wanted %q", tC.in, got, tC.name)
+		}
+		if got := lowerFirst(tC.name); got != tC.param {
+			t.Errorf("lowerFirst(%q): got %q, wanted %q", tC.name, got, tC.param)
+		}
+	}
+}
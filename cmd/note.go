@@ -0,0 +1,211 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func noteCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("note-add")
+	private := FS.BoolLongDefault("private", false, "add a private note")
+	spent := FS.StringLong("time", "", "time spent, as 1h30m, 1:30 or minutes")
+	file := FS.StringLong("file", "", `read the note from this file ("-" for stdin)`)
+	addCmd := &ff.Command{Name: "add", Usage: "add [flags] <issueID> [text...]", Flags: FS,
+		ShortHelp: "add a note to an issue; without text and --file, $EDITOR is started",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			if len(args) > 1 && *file != "" {
+				return fmt.Errorf("the text and --file are mutually exclusive")
+			}
+			note := mantis.IssueNoteData{Reporter: cl.User, Text: strings.Join(args[1:], " ")}
+			if note.Text == "" {
+				if note.Text, err = readText(*file, ""); err != nil {
+					return fmt.Errorf("read note: %w", err)
+				}
+			}
+			if note.Text = strings.TrimSpace(note.Text); note.Text == "" {
+				return fmt.Errorf("note text is required")
+			}
+			if *private {
+				vs, err := cl.Enums().Ref(ctx, mantis.EnumViewState, "private")
+				if err != nil {
+					return err
+				}
+				note.ViewState = &vs
+			}
+			if *spent != "" {
+				minutes, err := parseMinutes(*spent)
+				if err != nil {
+					return fmt.Errorf("--time=%q: %w", *spent, err)
+				}
+				note.TimeTracking = &minutes
+			}
+			noteID, err := cl.IssueNoteAdd(ctx, issueID, note)
+			if err != nil {
+				return err
+			}
+			fmt.Println(noteID)
+			return nil
+		},
+	}
+
+	listCmd := &ff.Command{Name: "list", Usage: "list <issueID>",
+		ShortHelp: "list the notes of an issue as JSON Lines",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			issue, err := cl.IssueGet(ctx, issueID)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			for _, n := range issue.Notes {
+				if err := enc.Encode(n); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	FS = ff.NewFlagSet("note-edit")
+	editIssue := FS.IntLong("issue", 0, "the issue of the note, to edit its current text")
+	editFile := FS.StringLong("file", "", `read the new text from this file ("-" for stdin)`)
+	editPrivate := FS.StringLong("private", "", "set the note private (true) or public (false)")
+	editCmd := &ff.Command{Name: "edit", Usage: "edit [--issue=ID | --file=F] <noteID>", Flags: FS,
+		ShortHelp: "edit the text of a note in $EDITOR",
+		LongHelp: "The SOAP API cannot fetch a note by its ID, " +
+			"so --issue is required to start $EDITOR with the current text.",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("noteID is required")
+			}
+			noteID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			note := mantis.IssueNoteData{ID: &noteID}
+			if *editFile != "" {
+				if note.Text, err = readText(*editFile, ""); err != nil {
+					return fmt.Errorf("read note: %w", err)
+				}
+			} else {
+				if *editIssue == 0 {
+					return fmt.Errorf("--issue or --file is required")
+				}
+				issue, err := cl.IssueGet(ctx, *editIssue)
+				if err != nil {
+					return err
+				}
+				var found bool
+				for _, n := range issue.Notes {
+					if found = n.ID == noteID; found {
+						note.Text = n.Text
+						break
+					}
+				}
+				if !found {
+					return fmt.Errorf("note %d not found in issue %d", noteID, *editIssue)
+				}
+				old := note.Text
+				if note.Text, err = editText(old); err != nil {
+					return err
+				}
+				if strings.TrimSpace(note.Text) == strings.TrimSpace(old) && *editPrivate == "" {
+					logger.Info("unchanged", "note", noteID)
+					return nil
+				}
+			}
+			if note.Text = strings.TrimSpace(note.Text); note.Text == "" {
+				return fmt.Errorf("note text is required")
+			}
+			if *editPrivate != "" {
+				private, err := strconv.ParseBool(*editPrivate)
+				if err != nil {
+					return fmt.Errorf("--private=%q: %w", *editPrivate, err)
+				}
+				name := "public"
+				if private {
+					name = "private"
+				}
+				vs, err := cl.Enums().Ref(ctx, mantis.EnumViewState, name)
+				if err != nil {
+					return err
+				}
+				note.ViewState = &vs
+			}
+			return cl.IssueNoteUpdate(ctx, note)
+		},
+	}
+
+	deleteCmd := &ff.Command{Name: "delete", Usage: "delete <noteID>...",
+		ShortHelp: "delete notes",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("noteID is required")
+			}
+			noteIDs, err := toInts(args)
+			if err != nil {
+				return err
+			}
+			for _, noteID := range noteIDs {
+				if err := cl.IssueNoteDelete(ctx, noteID); err != nil {
+					return fmt.Errorf("delete note %d: %w", noteID, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	return &ff.Command{Name: "note", Usage: "do sth with notes",
+		Subcommands: []*ff.Command{addCmd, listCmd, editCmd, deleteCmd},
+	}
+}
+
+// parseMinutes parses the spent time as a duration (1h30m), hours:minutes (1:30) or minutes (90).
+func parseMinutes(s string) (int, error) {
+	if h, m, ok := strings.Cut(s, ":"); ok {
+		hours, err := strconv.Atoi(h)
+		if err != nil {
+			return 0, err
+		}
+		minutes, err := strconv.Atoi(m)
+		if err != nil {
+			return 0, err
+		}
+		return hours*60 + minutes, nil
+	}
+	if minutes, err := strconv.Atoi(s); err == nil {
+		return minutes, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return int(d.Round(time.Minute) / time.Minute), nil
+}
+
+// vim: set fileencoding=utf-8 noet:
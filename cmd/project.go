@@ -0,0 +1,284 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func projectAddCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-add")
+	description := FS.StringLong("description", "", "description")
+	status := FS.StringLong("status", "", "project status name or ID (default: development)")
+	viewState := FS.StringLong("view-state", "", "view state name or ID (default: public)")
+	disabled := FS.BoolLongDefault("disabled", false, "create the project disabled")
+	inheritGlobal := FS.BoolLongDefault("inherit-global", true, "inherit the global categories")
+	return &ff.Command{Name: "add", Usage: "add [flags] <name>", Flags: FS,
+		ShortHelp: "create a project, printing its ID",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project name is required")
+			}
+			p := mantis.ProjectData{Name: args[0], Description: *description,
+				Enabled: !*disabled, InheritGlobal: inheritGlobal}
+			if err := setProjectEnums(ctx, cl, &p, *status, *viewState); err != nil {
+				return err
+			}
+			id, err := cl.ProjectAdd(ctx, p)
+			if err != nil {
+				return err
+			}
+			fmt.Println(id)
+			return nil
+		},
+	}
+}
+
+func projectUpdateCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-update")
+	name := FS.StringLong("name", "", "new name")
+	description := FS.StringLong("description", "", "new description")
+	status := FS.StringLong("status", "", "project status name or ID")
+	viewState := FS.StringLong("view-state", "", "view state name or ID")
+	enabled := FS.StringLong("enabled", "", "enable (true) or disable (false) the project")
+	inheritGlobal := FS.StringLong("inherit-global", "", "inherit (true) or not (false) the global categories")
+	return &ff.Command{Name: "update", Usage: "update [flags] <project name or ID>", Flags: FS,
+		ShortHelp: "update a project, leaving the fields without flags as is",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project is required")
+			}
+			p, err := resolveProject(ctx, cl, args[0])
+			if err != nil {
+				return err
+			}
+			if *name != "" {
+				p.Name = *name
+			}
+			if *description != "" {
+				p.Description = *description
+			}
+			if *enabled != "" {
+				if p.Enabled, err = strconv.ParseBool(*enabled); err != nil {
+					return fmt.Errorf("--enabled=%q: %w", *enabled, err)
+				}
+			}
+			if *inheritGlobal != "" {
+				v, err := strconv.ParseBool(*inheritGlobal)
+				if err != nil {
+					return fmt.Errorf("--inherit-global=%q: %w", *inheritGlobal, err)
+				}
+				p.InheritGlobal = &v
+			}
+			if err := setProjectEnums(ctx, cl, &p, *status, *viewState); err != nil {
+				return err
+			}
+			p.Subprojects = nil
+			return cl.ProjectUpdate(ctx, p.ID, p)
+		},
+	}
+}
+
+func projectDeleteCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("project-delete")
+	yes := FS.BoolLongDefault("yes", false, "really delete the project, with all its issues")
+	return &ff.Command{Name: "delete", Usage: "delete [--yes] <project name or ID>", Flags: FS,
+		ShortHelp: "delete a project with all its issues; prints the number of issues first",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project is required")
+			}
+			p, err := resolveProject(ctx, cl, args[0])
+			if err != nil {
+				return err
+			}
+			// The issues of the subprojects are listed, too, but not deleted.
+			var n int
+			for header, err := range cl.AllProjectIssueHeaders(ctx, p.ID, 0) {
+				if err != nil {
+					return err
+				}
+				if header.Project == p.ID {
+					n++
+				}
+			}
+			fmt.Printf("Project %q (%d) has %d issues and %d subprojects.\n", p.Name, p.ID, n, len(p.Subprojects))
+			if !*yes {
+				return fmt.Errorf("--yes is required to delete project %q with its %d issues", p.Name, n)
+			}
+			if err := cl.ProjectDelete(ctx, p.ID); err != nil {
+				return err
+			}
+			fmt.Printf("Project %q (%d) deleted.\n", p.Name, p.ID)
+			return nil
+		},
+	}
+}
+
+func projectTreeCmd(cl *mantis.Client) *ff.Command {
+	return &ff.Command{Name: "tree", Usage: "tree [project name or ID]",
+		ShortHelp: "print the hierarchy of the projects",
+		Exec: func(ctx context.Context, args []string) error {
+			var projects []mantis.ProjectData
+			switch len(args) {
+			case 0:
+				var err error
+				if projects, err = cl.ProjectsGetUserAccessible(ctx); err != nil {
+					return err
+				}
+			case 1:
+				p, err := resolveProject(ctx, cl, args[0])
+				if err != nil {
+					return err
+				}
+				projects = []mantis.ProjectData{p}
+			default:
+				return fmt.Errorf("at most one project is accepted")
+			}
+			writeProjectTree(os.Stdout, projects, "")
+			return nil
+		},
+	}
+}
+
+func projectFieldsCmd(cl *mantis.Client) *ff.Command {
+	return &ff.Command{Name: "fields", Usage: "fields <project name or ID>",
+		ShortHelp: "list the custom field definitions of the project as JSON Lines",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("project is required")
+			}
+			p, err := resolveProject(ctx, cl, args[0])
+			if err != nil {
+				return err
+			}
+			defs, err := cl.ProjectGetCustomFields(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(os.Stdout)
+			for _, d := range defs {
+				if err := enc.Encode(d); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func projectVersionReleaseCmd(cl *mantis.Client, parent *ff.FlagSet, projectID *int) *ff.Command {
+	FS := ff.NewFlagSet("project-version-release").SetParent(parent)
+	moveTo := FS.StringLong("move-to", "", "move the open issues to this version (default: --next or the next unreleased version)")
+	next := FS.StringLong("next", "", "create this new, unreleased version")
+	date := FS.StringLong("date", "", "release date (YYYY-MM-DD, default: today)")
+	return &ff.Command{Name: "release", Usage: "release --project=ID [flags] <version name>", Flags: FS,
+		ShortHelp: "release a version, moving its open issues to the next version",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("version name is required")
+			}
+			if *projectID == 0 {
+				return fmt.Errorf("--project is required")
+			}
+			opts := mantis.ReleaseOptions{MoveTo: *moveTo, Next: *next}
+			if *date != "" {
+				var err error
+				if opts.Date, err = time.ParseInLocation(time.DateOnly, *date, time.Local); err != nil {
+					return fmt.Errorf("--date=%q: %w", *date, err)
+				}
+			}
+			rel, err := cl.ReleaseVersion(ctx, *projectID, args[0], opts)
+			if rel.NextID != 0 {
+				fmt.Printf("Created version %q (%d).\n", *next, rel.NextID)
+			}
+			if len(rel.Moved) != 0 {
+				fmt.Printf("Moved %d issues to %q: %s\n", len(rel.Moved), rel.MovedTo, joinInts(rel.Moved))
+			}
+			if err != nil {
+				return err
+			}
+			if len(rel.Open) != 0 {
+				fmt.Printf("No version to move to, %d open issues left: %s\n", len(rel.Open), joinInts(rel.Open))
+			}
+			fmt.Printf("Released version %q (%d) on %s.\n", rel.Version.Name, rel.Version.ID,
+				versionDate(rel.Version).Format(time.DateOnly))
+			return nil
+		},
+	}
+}
+
+// versionDate returns the date of the version, or the zero time.
+func versionDate(v mantis.ProjectVersionData) time.Time {
+	if v.DateOrder == nil {
+		return time.Time{}
+	}
+	return time.Time(*v.DateOrder)
+}
+
+// joinInts returns the numbers separated by commas.
+func joinInts(ints []int) string {
+	ss := make([]string, len(ints))
+	for i, n := range ints {
+		ss[i] = strconv.Itoa(n)
+	}
+	return strings.Join(ss, ", ")
+}
+
+// writeProjectTree writes the projects and their subprojects, indented.
+func writeProjectTree(w io.Writer, projects []mantis.ProjectData, indent string) {
+	for i, p := range projects {
+		branch, next := "├── ", "│   "
+		if i == len(projects)-1 {
+			branch, next = "└── ", "    "
+		}
+		var attrs []string
+		if p.Status != nil && p.Status.Name != "" {
+			attrs = append(attrs, p.Status.Name)
+		}
+		if p.ViewState != nil && p.ViewState.Name != "" {
+			attrs = append(attrs, p.ViewState.Name)
+		}
+		if !p.Enabled {
+			attrs = append(attrs, "disabled")
+		}
+		fmt.Fprintf(w, "%s%s%s (%d) [%s]\n", indent, branch, p.Name, p.ID, strings.Join(attrs, ", "))
+		writeProjectTree(w, p.Subprojects, indent+next)
+	}
+}
+
+// setProjectEnums sets the status and the view state of the project, if not empty.
+func setProjectEnums(ctx context.Context, cl *mantis.Client, p *mantis.ProjectData, status, viewState string) error {
+	for _, x := range []struct {
+		dst  **mantis.ObjectRef
+		enum mantis.Enum
+		name string
+	}{
+		{&p.Status, mantis.EnumProjectStatus, status},
+		{&p.ViewState, mantis.EnumProjectViewState, viewState},
+	} {
+		if x.name == "" {
+			continue
+		}
+		ref, err := cl.Enums().Ref(ctx, x.enum, x.name)
+		if err != nil {
+			return err
+		}
+		*x.dst = &ref
+	}
+	return nil
+}
+
+// vim: set fileencoding=utf-8 noet:
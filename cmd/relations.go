@@ -0,0 +1,127 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func relationsCmd(cl *mantis.Client) *ff.Command {
+	addCmd := &ff.Command{Name: "add", Usage: "add <issueID> <type> <targetID>",
+		ShortHelp: "add a relationship (duplicate-of, related-to, parent-of, child-of, has-duplicate)",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 3 {
+				return fmt.Errorf("issueID, type and targetID are required")
+			}
+			typ, err := mantis.ParseRelationshipType(args[1])
+			if err != nil {
+				return err
+			}
+			ids, err := toInts([]string{args[0], args[2]})
+			if err != nil {
+				return err
+			}
+			id, err := cl.IssueRelationshipAdd(ctx, ids[0], typ, ids[1])
+			if err != nil {
+				return err
+			}
+			fmt.Println(id)
+			return nil
+		},
+	}
+	deleteCmd := &ff.Command{Name: "delete", Usage: "delete <issueID> <relationshipID>",
+		ShortHelp: "delete a relationship",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("issueID and relationshipID are required")
+			}
+			ids, err := toInts(args)
+			if err != nil {
+				return err
+			}
+			return cl.IssueRelationshipDelete(ctx, ids[0], ids[1])
+		},
+	}
+
+	FS := ff.NewFlagSet("issue-relations")
+	depth := FS.IntLong("depth", 1, "depth of the walk (negative for unlimited)")
+	dot := FS.BoolLongDefault("dot", false, "print Graphviz DOT instead of JSON")
+	return &ff.Command{Name: "relations", Usage: "relations [--depth N] [--dot] <issueID>", Flags: FS,
+		ShortHelp:   "print the relationship tree of the issue",
+		Subcommands: []*ff.Command{addCmd, deleteCmd},
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			tree, err := cl.IssueRelationshipTree(ctx, issueID, *depth)
+			if err != nil {
+				return err
+			}
+			if *dot {
+				return writeDot(os.Stdout, tree)
+			}
+			return E(tree)
+		},
+	}
+}
+
+// writeDot writes the relationship tree as a Graphviz digraph.
+func writeDot(w io.Writer, root *mantis.RelationshipNode) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph \"%s\" {\n\tnode [shape=box];\n", dotQuote("issue "+issueLabel(root)))
+	var walk func(*mantis.RelationshipNode)
+	walk = func(n *mantis.RelationshipNode) {
+		var label, style string
+		if n.Err != nil {
+			label, style = issueLabel(n)+"\n"+n.Err.Error(), " style=dashed"
+		} else {
+			label = issueLabel(n)
+			if n.Issue.Summary != nil {
+				label += " " + *n.Issue.Summary
+			}
+			if n.Issue.Status != nil {
+				label += "\n" + n.Issue.Status.Name
+			}
+		}
+		fmt.Fprintf(bw, "\t%s [label=\"%s\"%s];\n", issueLabel(n)[1:], dotQuote(label), style)
+		for _, c := range n.Children {
+			fmt.Fprintf(bw, "\t%s -> %s [label=\"%s\"];\n",
+				issueLabel(n)[1:], issueLabel(c)[1:], dotQuote(c.Relationship.RelationshipType().String()))
+			walk(c)
+		}
+	}
+	walk(root)
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
+
+// dotQuoter escapes a string for a quoted DOT ID, which knows only the \" and \\ escapes,
+// and \n as a line break in labels.
+var dotQuoter = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotQuote returns s escaped to be put between double quotes in a DOT file.
+func dotQuote(s string) string { return dotQuoter.Replace(s) }
+
+func issueLabel(n *mantis.RelationshipNode) string {
+	if n.Issue.ID == nil {
+		return "#0"
+	}
+	return "#" + strconv.Itoa(int(*n.Issue.ID))
+}
+
+// vim: set fileencoding=utf-8 noet:
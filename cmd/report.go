@@ -0,0 +1,141 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func reportCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("report-time")
+	projects := FS.StringListLong("project", "project name or ID (repeatable; default: all)")
+	month := FS.StringLong("month", "", "the month (YYYY-MM), instead of --since and --until")
+	since := FS.StringLong("since", "", "first day (YYYY-MM-DD)")
+	until := FS.StringLong("until", "", "last day (YYYY-MM-DD), inclusive")
+	users := FS.StringListLong("user", "username (repeatable; default: all)")
+	by := FS.StringLong("by", "user", "group by user, issue, day or entry (for table and csv)")
+	format := FS.StringLong("format", "table", "output format: table, csv or json")
+	timeCmd := &ff.Command{Name: "time", Usage: "time [flags]", Flags: FS,
+		ShortHelp: "sum the time tracked in the notes",
+		Exec: func(ctx context.Context, args []string) error {
+			switch *format {
+			case "table", "csv", "json":
+			default:
+				return fmt.Errorf("--format=%q: unknown format", *format)
+			}
+			var filter mantis.TimeFilter
+			var err error
+			if *month != "" {
+				if *since != "" || *until != "" {
+					return fmt.Errorf("--month excludes --since and --until")
+				}
+				if filter.Since, err = time.ParseInLocation("2006-01", *month, time.Local); err != nil {
+					return fmt.Errorf("--month=%q: %w", *month, err)
+				}
+				filter.Until = filter.Since.AddDate(0, 1, 0)
+			}
+			if *since != "" {
+				if filter.Since, err = time.ParseInLocation(time.DateOnly, *since, time.Local); err != nil {
+					return fmt.Errorf("--since=%q: %w", *since, err)
+				}
+			}
+			if *until != "" {
+				if filter.Until, err = time.ParseInLocation(time.DateOnly, *until, time.Local); err != nil {
+					return fmt.Errorf("--until=%q: %w", *until, err)
+				}
+				filter.Until = filter.Until.AddDate(0, 0, 1)
+			}
+			for _, nameOrID := range *projects {
+				p, err := resolveProject(ctx, cl, nameOrID)
+				if err != nil {
+					return err
+				}
+				filter.ProjectIDs = append(filter.ProjectIDs, p.ID)
+			}
+			filter.Users = *users
+
+			report, err := cl.TimeReport(ctx, filter)
+			if err != nil {
+				return err
+			}
+			if *format == "json" {
+				return E(report)
+			}
+
+			var header []string
+			var rows [][]string
+			switch *by {
+			case "entry":
+				header = []string{"date", "user", "issue", "summary", "note", "minutes", "hours"}
+				for _, e := range report.Entries {
+					rows = append(rows, []string{
+						e.Date.Format("2006-01-02 15:04"), e.User,
+						strconv.Itoa(e.IssueID), e.Summary, strconv.Itoa(e.NoteID),
+						strconv.Itoa(e.Minutes), formatHours(e.Minutes),
+					})
+				}
+			case "user", "issue", "day":
+				totals := map[string][]mantis.TimeTotal{
+					"user": report.ByUser, "issue": report.ByIssue, "day": report.ByDay,
+				}[*by]
+				header = []string{*by, "minutes", "hours"}
+				if *by == "issue" {
+					header = []string{"issue", "summary", "minutes", "hours"}
+				}
+				for _, t := range totals {
+					row := []string{t.Key}
+					if *by == "issue" {
+						row = append(row, t.Label)
+					}
+					rows = append(rows, append(row, strconv.Itoa(t.Minutes), formatHours(t.Minutes)))
+				}
+			default:
+				return fmt.Errorf("--by=%q: unknown grouping", *by)
+			}
+
+			if *format == "csv" {
+				w := csv.NewWriter(os.Stdout)
+				w.Write(header)
+				w.WriteAll(rows)
+				return w.Error()
+			}
+			tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			for i, h := range header {
+				header[i] = strings.ToUpper(h)
+			}
+			total := make([]string, len(header))
+			total[0] = "TOTAL"
+			total[len(total)-2], total[len(total)-1] = strconv.Itoa(report.Minutes), formatHours(report.Minutes)
+			for _, row := range append(append([][]string{header}, rows...), total) {
+				for _, s := range row {
+					fmt.Fprintf(tw, "%s\t", s)
+				}
+				fmt.Fprintln(tw)
+			}
+			return tw.Flush()
+		},
+	}
+	return &ff.Command{Name: "report", Usage: "report time",
+		ShortHelp:   "reports",
+		Subcommands: []*ff.Command{timeCmd},
+	}
+}
+
+// formatHours returns the minutes as decimal hours, such as 1.50 for 90.
+func formatHours(minutes int) string {
+	return strconv.FormatFloat(float64(minutes)/60, 'f', 2, 64)
+}
+
+// vim: set fileencoding=utf-8 noet:
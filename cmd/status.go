@@ -0,0 +1,112 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func statusCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("issue-status")
+	resolution := FS.StringLong("resolution", "", "resolution name or ID")
+	fixedIn := FS.StringLong("fixed-in", "", "fixed in version")
+	handler := FS.StringLong("handler", "", "handler's username")
+	note := FS.StringLong("note", "", "note to add")
+	allowBackward := FS.BoolLongDefault("allow-backward", false, "allow setting a lower status (e.g. reopen)")
+	return &ff.Command{Name: "status", Usage: "status [flags] <status name or ID> <issueID>...", Flags: FS,
+		ShortHelp: "set issues' status, printing the result per issue",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 2 {
+				return fmt.Errorf("status and issue IDs are required")
+			}
+			status, err := cl.Enums().Ref(ctx, mantis.EnumStatus, args[0])
+			if err != nil {
+				return err
+			}
+			issueIDs, err := toInts(args[1:])
+			if err != nil {
+				return err
+			}
+			var patch mantis.IssuePatch
+			patch.Status = &status
+			if *resolution != "" {
+				ref, err := cl.Enums().Ref(ctx, mantis.EnumResolution, *resolution)
+				if err != nil {
+					return err
+				}
+				patch.Resolution = &ref
+			}
+			if *fixedIn != "" {
+				patch.FixedInVersion = fixedIn
+			}
+			if *note != "" {
+				patch.Notes = []mantis.NoteData{{Text: *note}}
+			}
+			changesMore := patch.Resolution != nil || patch.FixedInVersion != nil ||
+				*handler != "" || patch.Notes != nil
+			// The handler is resolved per project.
+			handlers := make(map[int]mantis.AccountData)
+
+			setStatus := func(issueID int) (from, result string, err error) {
+				issue, err := cl.IssueGet(ctx, issueID)
+				if err != nil {
+					return "", "", err
+				}
+				if issue.Status != nil {
+					from = issue.Status.Name
+					switch {
+					case issue.Status.ID > status.ID && !*allowBackward:
+						return from, "skipped: backward", nil
+					case issue.Status.ID == status.ID && !changesMore:
+						return from, "unchanged", nil
+					}
+				}
+				p := patch
+				p.IfUnmodifiedSince = lastUpdated(issue)
+				if *handler != "" && issue.Project != nil {
+					u, ok := handlers[issue.Project.ID]
+					if !ok {
+						if u, err = resolveUser(ctx, cl, issue.Project.ID, *handler); err != nil {
+							return from, "", err
+						}
+						handlers[issue.Project.ID] = u
+					}
+					p.Handler = &u
+				}
+				if err := cl.IssuePatch(ctx, issueID, p); err != nil {
+					return from, "", err
+				}
+				return from, "updated", nil
+			}
+
+			tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(tw, "ISSUE\tFROM\tTO\tRESULT")
+			var failed int
+			for _, issueID := range issueIDs {
+				from, result, err := setStatus(issueID)
+				if err != nil {
+					failed++
+					result = "error: " + err.Error()
+				}
+				fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", issueID, from, status.Name, result)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+			if failed != 0 {
+				return fmt.Errorf("%d of %d issues failed", failed, len(issueIDs))
+			}
+			return nil
+		},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,115 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantiscmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/peterbourgon/ff/v4"
+	"github.com/tgulacsi/mantis-soap"
+)
+
+func tagCmd(cl *mantis.Client) *ff.Command {
+	FS := ff.NewFlagSet("tag-list")
+	perPage := FS.IntLong("per-page", mantis.DefaultPerPage, "page size of the queries")
+	listCmd := &ff.Command{Name: "list", Usage: "list", Flags: FS,
+		ShortHelp: "list all tags as JSON Lines",
+		Exec: func(ctx context.Context, args []string) error {
+			enc := json.NewEncoder(os.Stdout)
+			for tag, err := range cl.AllTags(ctx, *perPage) {
+				if err != nil {
+					return err
+				}
+				if err := enc.Encode(tag); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	FS = ff.NewFlagSet("tag-add")
+	description := FS.StringLong("description", "", "tag description")
+	addCmd := &ff.Command{Name: "add", Usage: "add [--description=...] <name>", Flags: FS,
+		ShortHelp: "create a tag, printing its ID",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("tag name is required")
+			}
+			id, err := cl.TagAdd(ctx, args[0], *description)
+			if err != nil {
+				return err
+			}
+			fmt.Println(id)
+			return nil
+		},
+	}
+
+	deleteCmd := &ff.Command{Name: "delete", Usage: "delete <name or ID>...",
+		ShortHelp: "delete tags",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("tag name or ID is required")
+			}
+			ids := make(map[string]int, len(args))
+			for tag, err := range cl.AllTags(ctx, 0) {
+				if err != nil {
+					return err
+				}
+				ids[tag.Name] = tag.ID
+				ids[strconv.Itoa(tag.ID)] = tag.ID
+			}
+			for _, a := range args {
+				id, ok := ids[a]
+				if !ok {
+					return fmt.Errorf("tag %q not found", a)
+				}
+				if err := cl.TagDelete(ctx, id); err != nil {
+					return fmt.Errorf("delete tag %q: %w", a, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	return &ff.Command{Name: "tag", Usage: "do sth with tags",
+		Subcommands: []*ff.Command{listCmd, addCmd, deleteCmd},
+	}
+}
+
+func issueTagCmd(cl *mantis.Client) *ff.Command {
+	return &ff.Command{Name: "tag", Usage: "tag <issueID> [+]add... -remove...",
+		ShortHelp: "attach (+name) and detach (-name) tags, creating the missing ones",
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("issueID is required")
+			}
+			issueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			var add, remove []string
+			for _, a := range args[1:] {
+				if name, ok := strings.CutPrefix(a, "-"); ok {
+					remove = append(remove, name)
+				} else {
+					add = append(add, strings.TrimPrefix(a, "+"))
+				}
+			}
+			tags, err := cl.IssueEditTags(ctx, issueID, add, remove)
+			if err != nil {
+				return err
+			}
+			return E(tags)
+		},
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,262 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CustomFieldType is the type of a custom field, as in mc_enum_custom_field_types.
+type CustomFieldType int
+
+const (
+	CustomFieldString    = CustomFieldType(0)
+	CustomFieldNumeric   = CustomFieldType(1)
+	CustomFieldFloat     = CustomFieldType(2)
+	CustomFieldEnum      = CustomFieldType(3)
+	CustomFieldEmail     = CustomFieldType(4)
+	CustomFieldCheckbox  = CustomFieldType(5)
+	CustomFieldList      = CustomFieldType(6)
+	CustomFieldMultiList = CustomFieldType(7)
+	CustomFieldDate      = CustomFieldType(8)
+	CustomFieldRadio     = CustomFieldType(9)
+	CustomFieldTextarea  = CustomFieldType(10)
+)
+
+var customFieldTypeNames = map[CustomFieldType]string{
+	CustomFieldString:    "string",
+	CustomFieldNumeric:   "numeric",
+	CustomFieldFloat:     "float",
+	CustomFieldEnum:      "enum",
+	CustomFieldEmail:     "email",
+	CustomFieldCheckbox:  "checkbox",
+	CustomFieldList:      "list",
+	CustomFieldMultiList: "multiselection list",
+	CustomFieldDate:      "date",
+	CustomFieldRadio:     "radio",
+	CustomFieldTextarea:  "textarea",
+}
+
+func (t CustomFieldType) String() string {
+	if s, ok := customFieldTypeNames[t]; ok {
+		return s
+	}
+	return strconv.Itoa(int(t))
+}
+
+// ProjectGetCustomFields returns the definitions of the custom fields linked to the project.
+func (c Client) ProjectGetCustomFields(ctx context.Context, projectID int) ([]CustomFieldDefinitionData, error) {
+	var resp ProjectGetCustomFieldsResponse
+	err := c.Call(ctx, "mc_project_get_custom_fields",
+		ProjectGetCustomFieldsRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// CustomField returns the value of the custom field by name (case-insensitively),
+// and whether the issue has it.
+func (issue IssueData) CustomField(name string) (string, bool) {
+	for _, f := range issue.CustomFields {
+		if strings.EqualFold(f.Field.Name, name) {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetCustomField sets the value of the custom field by name, adding it if missing.
+func (issue *IssueData) SetCustomField(name, value string) {
+	for i, f := range issue.CustomFields {
+		if strings.EqualFold(f.Field.Name, name) {
+			issue.CustomFields[i].Value = value
+			return
+		}
+	}
+	issue.CustomFields = append(issue.CustomFields, CustomFieldData{Field: ObjectRef{Name: name}, Value: value})
+}
+
+// CustomFieldTime returns the value of the date custom field.
+// The zero time is returned for a missing or empty field.
+func (issue IssueData) CustomFieldTime(name string) (time.Time, error) {
+	s, _ := issue.CustomField(name)
+	return ParseCustomFieldTime(s)
+}
+
+// CustomFieldNumber returns the value of the numeric or float custom field.
+// Zero is returned for a missing or empty field.
+func (issue IssueData) CustomFieldNumber(name string) (float64, error) {
+	s, _ := issue.CustomField(name)
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// CustomFieldList returns the values of the checkbox or multiselection list custom field.
+func (issue IssueData) CustomFieldList(name string) []string {
+	s, _ := issue.CustomField(name)
+	return ParseCustomFieldList(s)
+}
+
+// ParseCustomFieldTime parses the value of a date custom field: Unix seconds.
+func ParseCustomFieldTime(s string) (time.Time, error) {
+	if s = strings.TrimSpace(s); s == "" || s == "0" {
+		return time.Time{}, nil
+	}
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse date %q: %w", s, err)
+	}
+	return time.Unix(sec, 0), nil
+}
+
+// FormatCustomFieldTime formats the time as the value of a date custom field.
+func FormatCustomFieldTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// ParseCustomFieldList splits the value of a checkbox or multiselection list custom field.
+func ParseCustomFieldList(s string) []string {
+	s = strings.Trim(s, "|")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "|")
+}
+
+// FormatCustomFieldList joins the values for a checkbox or multiselection list custom field.
+func FormatCustomFieldList(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return "|" + strings.Join(values, "|") + "|"
+}
+
+// PossibleValueList returns the possible values of an enum, list, checkbox or radio field.
+func (d CustomFieldDefinitionData) PossibleValueList() []string {
+	if d.PossibleValues == "" {
+		return nil
+	}
+	return strings.Split(d.PossibleValues, "|")
+}
+
+// staticPossibleValues returns the PossibleValueList, or nil for the dynamic ones
+// (such as =versions or =categories), which are computed by Mantis.
+func (d CustomFieldDefinitionData) staticPossibleValues() []string {
+	if strings.HasPrefix(d.PossibleValues, "=") {
+		return nil
+	}
+	return d.PossibleValueList()
+}
+
+// Validate checks the value against the type, the possible values, the length limits
+// and the regexp of the field definition. The empty value is always valid,
+// and the dynamic possible values (such as =versions) are not checked.
+func (d CustomFieldDefinitionData) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	invalid := func(msg string) error {
+		return fmt.Errorf("custom field %q: %s: %w", d.Field.Name, msg, ErrInvalidCustomField)
+	}
+	var err error
+	switch d.Type {
+	case CustomFieldNumeric:
+		_, err = strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	case CustomFieldFloat:
+		_, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+	case CustomFieldDate:
+		_, err = ParseCustomFieldTime(value)
+	case CustomFieldEmail:
+		_, err = mail.ParseAddress(value)
+	case CustomFieldEnum, CustomFieldList, CustomFieldRadio:
+		if possible := d.staticPossibleValues(); len(possible) != 0 && !slices.Contains(possible, value) {
+			return invalid(fmt.Sprintf("%q is not one of %q", value, possible))
+		}
+	case CustomFieldCheckbox, CustomFieldMultiList:
+		possible := d.staticPossibleValues()
+		for _, v := range ParseCustomFieldList(value) {
+			if len(possible) != 0 && !slices.Contains(possible, v) {
+				return invalid(fmt.Sprintf("%q is not one of %q", v, possible))
+			}
+		}
+	}
+	if err != nil {
+		return invalid(fmt.Sprintf("%q is not a valid %s: %v", value, d.Type, err))
+	}
+	if n := utf8.RuneCountInString(value); (d.LengthMin > 0 && n < d.LengthMin) || (d.LengthMax > 0 && n > d.LengthMax) {
+		return invalid(fmt.Sprintf("length of %q is not between %d and %d", value, d.LengthMin, d.LengthMax))
+	}
+	// Mantis uses PCRE: the incompatible regexps are skipped.
+	if d.ValidRegexp != "" {
+		if rx, err := regexp.Compile(d.ValidRegexp); err == nil && !rx.MatchString(value) {
+			return invalid(fmt.Sprintf("%q does not match %q", value, d.ValidRegexp))
+		}
+	}
+	return nil
+}
+
+// ValidateCustomFields checks the custom fields against the definitions of the project:
+// they must be linked to the project and have valid values.
+// IssueAdd, IssueUpdate and IssuePatch call it for the custom fields they send.
+//
+// The read/write access levels are not checked.
+func (c Client) ValidateCustomFields(ctx context.Context, projectID int, fields []CustomFieldData) error {
+	return c.validateCustomFields(ctx, projectID, fields, false)
+}
+
+// validateCustomFields is ValidateCustomFields, also checking the fields required on report
+// when adding a new issue. Without fields, it does not call the server - Mantis checks those anyway.
+func (c Client) validateCustomFields(ctx context.Context, projectID int, fields []CustomFieldData, adding bool) error {
+	if len(fields) == 0 || projectID == 0 {
+		return nil
+	}
+	defs, err := c.ProjectGetCustomFields(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("get custom fields of project %d: %w", projectID, err)
+	}
+	var errs []error
+	for _, f := range fields {
+		i := slices.IndexFunc(defs, func(d CustomFieldDefinitionData) bool {
+			return (f.Field.ID != 0 && d.Field.ID == f.Field.ID) ||
+				(f.Field.ID == 0 && strings.EqualFold(d.Field.Name, f.Field.Name))
+		})
+		if i < 0 {
+			errs = append(errs, fmt.Errorf("custom field %q (%d) is not linked to project %d: %w",
+				f.Field.Name, f.Field.ID, projectID, ErrInvalidCustomField))
+			continue
+		}
+		if err := defs[i].Validate(f.Value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if adding {
+		for _, d := range defs {
+			if !d.RequireReport {
+				continue
+			}
+			if !slices.ContainsFunc(fields, func(f CustomFieldData) bool {
+				return f.Value != "" && (f.Field.ID == d.Field.ID || strings.EqualFold(f.Field.Name, d.Field.Name))
+			}) {
+				errs = append(errs, fmt.Errorf("custom field %q is required: %w", d.Field.Name, ErrInvalidCustomField))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,198 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestCustomFieldValidate(t *testing.T) {
+	for _, tc := range []struct {
+		def   mantis.CustomFieldDefinitionData
+		value string
+		ok    bool
+	}{
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldNumeric}, "", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldNumeric}, "42", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldNumeric}, "4.2", false},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldFloat}, "4.2", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldDate}, "1767225600", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldDate}, "2026-01-01", false},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldEmail}, "a@example.com", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldEmail}, "a.example.com", false},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldList, PossibleValues: "x|y"}, "y", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldList, PossibleValues: "x|y"}, "z", false},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldEnum, PossibleValues: "=versions"}, "1.0", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldMultiList, PossibleValues: "=categories"}, "|ui|docs|", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldCheckbox, PossibleValues: "x|y"}, "|x|y|", true},
+		{mantis.CustomFieldDefinitionData{Type: mantis.CustomFieldMultiList, PossibleValues: "x|y"}, "|x|z|", false},
+		{mantis.CustomFieldDefinitionData{LengthMin: 2, LengthMax: 3}, "árvíz", false},
+		{mantis.CustomFieldDefinitionData{LengthMin: 2, LengthMax: 5}, "árvíz", true},
+		{mantis.CustomFieldDefinitionData{ValidRegexp: `^[A-Z]+-\d+$`}, "ABC-12", true},
+		{mantis.CustomFieldDefinitionData{ValidRegexp: `^[A-Z]+-\d+$`}, "abc", false},
+	} {
+		err := tc.def.Validate(tc.value)
+		if tc.ok && err != nil {
+			t.Errorf("%+v %q: %+v", tc.def, tc.value, err)
+		} else if !tc.ok && !errors.Is(err, mantis.ErrInvalidCustomField) {
+			t.Errorf("%+v %q: got %+v, wanted ErrInvalidCustomField", tc.def, tc.value, err)
+		}
+	}
+}
+
+func TestCustomFieldAccess(t *testing.T) {
+	issue := mantis.IssueData{CustomFields: []mantis.CustomFieldData{
+		{Field: mantis.ObjectRef{ID: 1, Name: "Due"}, Value: "1767225600"},
+		{Field: mantis.ObjectRef{ID: 2, Name: "Cost"}, Value: "1.5"},
+		{Field: mantis.ObjectRef{ID: 3, Name: "OS"}, Value: "|linux|bsd|"},
+	}}
+	if v, ok := issue.CustomField("due"); !ok || v != "1767225600" {
+		t.Errorf("got %q, %t", v, ok)
+	}
+	if _, ok := issue.CustomField("missing"); ok {
+		t.Error("got missing field")
+	}
+	if due, err := issue.CustomFieldTime("Due"); err != nil || !due.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v, %+v", due, err)
+	}
+	if cost, err := issue.CustomFieldNumber("Cost"); err != nil || cost != 1.5 {
+		t.Errorf("got %v, %+v", cost, err)
+	}
+	if os := issue.CustomFieldList("OS"); !slices.Equal(os, []string{"linux", "bsd"}) {
+		t.Errorf("got %q", os)
+	}
+	issue.SetCustomField("os", mantis.FormatCustomFieldList([]string{"windows"}))
+	issue.SetCustomField("New", "x")
+	if len(issue.CustomFields) != 4 || issue.CustomFields[2].Value != "|windows|" || issue.CustomFields[3].Field.Name != "New" {
+		t.Errorf("got %+v", issue.CustomFields)
+	}
+}
+
+func TestCustomFieldValidation(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	for _, def := range []mantis.CustomFieldDefinitionData{
+		{Field: mantis.ObjectRef{Name: "Cost"}, Type: mantis.CustomFieldNumeric, RequireReport: true},
+		{Field: mantis.ObjectRef{Name: "Color"}, Type: mantis.CustomFieldEnum, PossibleValues: "red|green"},
+	} {
+		if _, err := srv.AddCustomField(projectID, def); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defs, err := cl.ProjectGetCustomFields(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defs) != 2 || defs[0].Type != mantis.CustomFieldNumeric || !defs[0].RequireReport ||
+		defs[1].PossibleValues != "red|green" {
+		t.Errorf("got %+v", defs)
+	}
+
+	summary, description := "summary", "description"
+	issue := mantis.IssueData{Project: &mantis.ObjectRef{ID: projectID}, Summary: &summary, Description: &description}
+	issue.SetCustomField("Color", "red")
+	if _, err = cl.IssueAdd(ctx, issue); !errors.Is(err, mantis.ErrInvalidCustomField) {
+		t.Errorf("got %+v for missing required field", err)
+	}
+	issue.SetCustomField("Cost", "cheap")
+	if _, err = cl.IssueAdd(ctx, issue); !errors.Is(err, mantis.ErrInvalidCustomField) {
+		t.Errorf("got %+v for invalid numeric field", err)
+	}
+	issue.SetCustomField("Cost", "12")
+	if _, err = cl.IssueAdd(ctx, issue); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []mantis.CustomFieldData{
+		{Field: mantis.ObjectRef{Name: "Color"}, Value: "blue"},
+		{Field: mantis.ObjectRef{Name: "Size"}, Value: "big"},
+	} {
+		if err = cl.ValidateCustomFields(ctx, projectID, []mantis.CustomFieldData{f}); !errors.Is(err, mantis.ErrInvalidCustomField) {
+			t.Errorf("%+v: got %+v, wanted ErrInvalidCustomField", f, err)
+		}
+	}
+	if err = cl.ValidateCustomFields(ctx, projectID,
+		[]mantis.CustomFieldData{{Field: mantis.ObjectRef{Name: "color"}, Value: "green"}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the sent custom fields are validated, not the stored ones,
+	// which may not fit their (changed) definition anymore.
+	if _, err = srv.AddCustomField(projectID, mantis.CustomFieldDefinitionData{
+		Field: mantis.ObjectRef{Name: "Since"}, Type: mantis.CustomFieldEnum, PossibleValues: "=versions",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	staleID := srv.NewIssue(t, projectID, mantis.IssueData{CustomFields: []mantis.CustomFieldData{
+		{Field: mantis.ObjectRef{Name: "Cost"}, Value: "12"},
+		{Field: mantis.ObjectRef{Name: "Color"}, Value: "purple"},
+	}})
+	tr := &opsTransport{RoundTripper: http.DefaultTransport}
+	cl = srv.Login(t, &http.Client{Transport: tr}, mantistest.DefaultUser, mantistest.DefaultPassword)
+	stale, err := cl.IssueGet(ctx, staleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := []mantis.CustomFieldData{{Field: mantis.ObjectRef{Name: "Since"}, Value: "1.0"}}
+	stale.CustomFields = since
+	if _, err = cl.IssueUpdate(ctx, staleID, stale); err != nil {
+		t.Fatal(err)
+	}
+	if err = cl.IssuePatch(ctx, staleID, mantis.IssuePatch{CustomFields: since}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The invalid values do not reach the server.
+	tr.reset()
+	blue := []mantis.CustomFieldData{{Field: mantis.ObjectRef{Name: "Color"}, Value: "blue"}}
+	stale.CustomFields = blue
+	if _, err = cl.IssueUpdate(ctx, staleID, stale); !errors.Is(err, mantis.ErrInvalidCustomField) {
+		t.Errorf("IssueUpdate: got %+v, wanted ErrInvalidCustomField", err)
+	}
+	if err = cl.IssuePatch(ctx, staleID, mantis.IssuePatch{CustomFields: blue}); !errors.Is(err, mantis.ErrInvalidCustomField) {
+		t.Errorf("IssuePatch: got %+v, wanted ErrInvalidCustomField", err)
+	}
+	if ops := tr.called(); slices.Contains(ops, "mc_issue_update") {
+		t.Errorf("called %q", ops)
+	}
+}
+
+// opsTransport records the called operations, by their SOAPAction.
+type opsTransport struct {
+	http.RoundTripper
+	mu  sync.Mutex
+	ops []string
+}
+
+func (t *opsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.ops = append(t.ops, path.Base(r.Header.Get("SOAPAction")))
+	t.mu.Unlock()
+	return t.RoundTripper.RoundTrip(r)
+}
+
+func (t *opsTransport) called() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Clone(t.ops)
+}
+
+func (t *opsTransport) reset() {
+	t.mu.Lock()
+	t.ops = nil
+	t.mu.Unlock()
+}
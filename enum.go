@@ -0,0 +1,304 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Enum is the name of an enumeration, as accepted by mc_enum_get.
+type Enum string
+
+const (
+	EnumStatus           = Enum("status")
+	EnumPriority         = Enum("priority")
+	EnumSeverity         = Enum("severity")
+	EnumReproducibility  = Enum("reproducibility")
+	EnumProjection       = Enum("projection")
+	EnumETA              = Enum("eta")
+	EnumResolution       = Enum("resolution")
+	EnumAccessLevel      = Enum("access_levels")
+	EnumProjectStatus    = Enum("project_status")
+	EnumProjectViewState = Enum("project_view_state")
+	EnumViewState        = Enum("view_state")
+	EnumCustomFieldType  = Enum("custom_field_type")
+)
+
+// DefaultEnumTTL is the default time the Enums registry caches the values.
+const DefaultEnumTTL = time.Hour
+
+func (c Client) PriorityEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp PriorityEnumResponse
+	err := c.Call(ctx, "mc_enum_priorities", PriorityEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) SeverityEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp SeverityEnumResponse
+	err := c.Call(ctx, "mc_enum_severities", SeverityEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ReproducibilityEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ReproducibilityEnumResponse
+	err := c.Call(ctx, "mc_enum_reproducibilities", ReproducibilityEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ProjectionEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ProjectionEnumResponse
+	err := c.Call(ctx, "mc_enum_projections", ProjectionEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ETAEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ETAEnumResponse
+	err := c.Call(ctx, "mc_enum_etas", ETAEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ResolutionEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ResolutionEnumResponse
+	err := c.Call(ctx, "mc_enum_resolutions", ResolutionEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) AccessLevelEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp AccessLevelEnumResponse
+	err := c.Call(ctx, "mc_enum_access_levels", AccessLevelEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ProjectStatusEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ProjectStatusEnumResponse
+	err := c.Call(ctx, "mc_enum_project_status", ProjectStatusEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ProjectViewStateEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ProjectViewStateEnumResponse
+	err := c.Call(ctx, "mc_enum_project_view_states", ProjectViewStateEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) ViewStateEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp ViewStateEnumResponse
+	err := c.Call(ctx, "mc_enum_view_states", ViewStateEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+func (c Client) CustomFieldTypeEnum(ctx context.Context) ([]ObjectRef, error) {
+	var resp CustomFieldTypeEnumResponse
+	err := c.Call(ctx, "mc_enum_custom_field_types", CustomFieldTypeEnumRequest{Auth: c.auth}, &resp)
+	return resp.Return, err
+}
+
+// EnumGet returns the raw enumeration string, such as "10:new,20:feedback".
+func (c Client) EnumGet(ctx context.Context, enumeration Enum) (string, error) {
+	var resp EnumGetResponse
+	err := c.Call(ctx, "mc_enum_get", EnumGetRequest{Auth: c.auth, Enumeration: string(enumeration)}, &resp)
+	return resp.Return, err
+}
+
+// Enum returns the values of the enumeration.
+//
+// The enumerations without a dedicated call are fetched with mc_enum_get.
+func (c Client) Enum(ctx context.Context, enum Enum) ([]ObjectRef, error) {
+	var f func(context.Context) ([]ObjectRef, error)
+	switch enum {
+	case EnumStatus:
+		f = c.StatusEnum
+	case EnumPriority:
+		f = c.PriorityEnum
+	case EnumSeverity:
+		f = c.SeverityEnum
+	case EnumReproducibility:
+		f = c.ReproducibilityEnum
+	case EnumProjection:
+		f = c.ProjectionEnum
+	case EnumETA:
+		f = c.ETAEnum
+	case EnumResolution:
+		f = c.ResolutionEnum
+	case EnumAccessLevel:
+		f = c.AccessLevelEnum
+	case EnumProjectStatus:
+		f = c.ProjectStatusEnum
+	case EnumProjectViewState:
+		f = c.ProjectViewStateEnum
+	case EnumViewState:
+		f = c.ViewStateEnum
+	case EnumCustomFieldType:
+		f = c.CustomFieldTypeEnum
+	default:
+		s, err := c.EnumGet(ctx, enum)
+		if err != nil {
+			return nil, err
+		}
+		return ParseEnumString(s)
+	}
+	return f(ctx)
+}
+
+// ParseEnumString parses the "10:new,20:feedback" format returned by mc_enum_get.
+func ParseEnumString(s string) ([]ObjectRef, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	refs := make([]ObjectRef, 0, len(parts))
+	for _, part := range parts {
+		id, name, ok := strings.Cut(part, ":")
+		if !ok {
+			return refs, fmt.Errorf("parse enum element %q: no colon", part)
+		}
+		i, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			return refs, fmt.Errorf("parse enum element %q: %w", part, err)
+		}
+		refs = append(refs, ObjectRef{ID: i, Name: strings.TrimSpace(name)})
+	}
+	return refs, nil
+}
+
+// Enums is a registry of the enumerations, fetching each on first use with its Client,
+// and caching them for the Client's EnumTTL.
+//
+// It is safe for concurrent use.
+type Enums struct {
+	client Client
+	cache  *enumCache
+}
+
+// enumCache is the cache of the Enums, shared by the copies of a Client.
+type enumCache struct {
+	entries map[Enum]*enumEntry
+	mu      sync.Mutex
+}
+
+// enumEntry is a cached enumeration, valid after done is closed.
+type enumEntry struct {
+	fetched time.Time
+	err     error
+	done    chan struct{}
+	values  []ObjectRef
+}
+
+// Enums returns the registry of the enumerations, fetching them with c.
+//
+// The cache is shared by the copies of the Client.
+func (c Client) Enums() Enums {
+	return Enums{client: c, cache: c.enums}
+}
+
+// Get returns the (possibly cached) values of the enumeration.
+//
+// The enumeration is fetched without holding the lock, once for the concurrent callers.
+func (e Enums) Get(ctx context.Context, enum Enum) ([]ObjectRef, error) {
+	if e.cache == nil {
+		values, err := e.client.Enum(ctx, enum)
+		if err != nil {
+			return nil, fmt.Errorf("get %s enum: %w", enum, err)
+		}
+		return values, nil
+	}
+	ttl := e.client.EnumTTL
+	if ttl <= 0 {
+		ttl = DefaultEnumTTL
+	}
+	e.cache.mu.Lock()
+	ent := e.cache.entries[enum]
+	if ent != nil {
+		select {
+		case <-ent.done:
+			if time.Since(ent.fetched) >= ttl {
+				ent = nil
+			}
+		default:
+		}
+	}
+	if ent == nil {
+		ent = &enumEntry{done: make(chan struct{})}
+		if e.cache.entries == nil {
+			e.cache.entries = make(map[Enum]*enumEntry)
+		}
+		e.cache.entries[enum] = ent
+		e.cache.mu.Unlock()
+
+		ent.values, ent.err = e.client.Enum(ctx, enum)
+		ent.fetched = time.Now()
+		close(ent.done)
+		if ent.err != nil {
+			e.cache.mu.Lock()
+			if e.cache.entries[enum] == ent {
+				delete(e.cache.entries, enum)
+			}
+			e.cache.mu.Unlock()
+		}
+	} else {
+		e.cache.mu.Unlock()
+		select {
+		case <-ent.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if ent.err != nil {
+		return nil, fmt.Errorf("get %s enum: %w", enum, ent.err)
+	}
+	return ent.values, nil
+}
+
+// Reset drops the cached values.
+func (e Enums) Reset() {
+	if e.cache == nil {
+		return
+	}
+	e.cache.mu.Lock()
+	e.cache.entries = nil
+	e.cache.mu.Unlock()
+}
+
+// Ref returns the value of the enumeration by its (case-insensitive) name,
+// or its ID, if nameOrID is a number.
+func (e Enums) Ref(ctx context.Context, enum Enum, nameOrID string) (ObjectRef, error) {
+	values, err := e.Get(ctx, enum)
+	if err != nil {
+		return ObjectRef{}, err
+	}
+	nameOrID = strings.TrimSpace(nameOrID)
+	id, idErr := strconv.Atoi(nameOrID)
+	for _, v := range values {
+		if (idErr == nil && v.ID == id) || strings.EqualFold(v.Name, nameOrID) {
+			return v, nil
+		}
+	}
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.Name
+	}
+	return ObjectRef{}, fmt.Errorf("unknown %s %q (valid: %s): %w",
+		enum, nameOrID, strings.Join(names, ", "), ErrUnknownEnumValue)
+}
+
+// ID returns the ID of the enumeration value by its name (or ID).
+func (e Enums) ID(ctx context.Context, enum Enum, name string) (int, error) {
+	ref, err := e.Ref(ctx, enum, name)
+	return ref.ID, err
+}
+
+// Name returns the name of the enumeration value by its ID.
+func (e Enums) Name(ctx context.Context, enum Enum, id int) (string, error) {
+	ref, err := e.Ref(ctx, enum, strconv.Itoa(id))
+	return ref.Name, err
+}
+
+// vim: set fileencoding=utf-8 noet:
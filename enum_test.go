@@ -0,0 +1,109 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+type countingTransport struct {
+	http.RoundTripper
+	n atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n.Add(1)
+	return t.RoundTripper.RoundTrip(r)
+}
+
+func TestEnums(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := mantistest.Start(t)
+	tr := &countingTransport{RoundTripper: http.DefaultTransport}
+	cl := srv.Login(t, &http.Client{Transport: tr}, mantistest.DefaultUser, mantistest.DefaultPassword)
+
+	for _, enum := range []mantis.Enum{
+		mantis.EnumStatus, mantis.EnumPriority, mantis.EnumSeverity, mantis.EnumReproducibility,
+		mantis.EnumProjection, mantis.EnumETA, mantis.EnumResolution, mantis.EnumAccessLevel,
+		mantis.EnumProjectStatus, mantis.EnumProjectViewState, mantis.EnumViewState,
+		mantis.EnumCustomFieldType,
+	} {
+		values, err := cl.Enum(ctx, enum)
+		if err != nil {
+			t.Fatalf("%s: %+v", enum, err)
+		}
+		if len(values) == 0 {
+			t.Errorf("%s: no values", enum)
+		}
+		s, err := cl.EnumGet(ctx, enum)
+		if err != nil {
+			t.Fatalf("get %s: %+v", enum, err)
+		}
+		parsed, err := mantis.ParseEnumString(s)
+		if err != nil {
+			t.Fatalf("parse %q: %+v", s, err)
+		}
+		if len(parsed) != len(values) || parsed[0] != values[0] {
+			t.Errorf("%s: got %+v, wanted %+v", enum, parsed, values)
+		}
+	}
+
+	n := tr.n.Load()
+	if id, err := cl.Enums().ID(ctx, mantis.EnumStatus, "Resolved"); err != nil || id != 80 {
+		t.Errorf("got %d, %+v, wanted 80", id, err)
+	}
+	if name, err := cl.Enums().Name(ctx, mantis.EnumStatus, 90); err != nil || name != "closed" {
+		t.Errorf("got %q, %+v, wanted closed", name, err)
+	}
+	if ref, err := cl.Enums().Ref(ctx, mantis.EnumStatus, "50"); err != nil || ref.Name != "assigned" {
+		t.Errorf("got %+v, %+v, wanted assigned", ref, err)
+	}
+	if _, err := cl.Enums().ID(ctx, mantis.EnumStatus, "nonexistent"); !errors.Is(err, mantis.ErrUnknownEnumValue) {
+		t.Errorf("got %+v, wanted ErrUnknownEnumValue", err)
+	}
+	if got := tr.n.Load() - n; got != 1 {
+		t.Errorf("got %d calls, wanted 1", got)
+	}
+	cl.Enums().Reset()
+	if _, err := cl.Enums().ID(ctx, mantis.EnumStatus, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tr.n.Load() - n; got != 2 {
+		t.Errorf("got %d calls after Reset, wanted 2", got)
+	}
+
+	// The copies share the cache, and the concurrent callers a single fetch.
+	cl.Enums().Reset()
+	copied := cl
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := copied.Enums().ID(ctx, mantis.EnumStatus, "new"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := cl.Enums().ID(ctx, mantis.EnumStatus, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if got := tr.n.Load() - n; got != 3 {
+		t.Errorf("got %d calls after concurrent Gets, wanted 3", got)
+	}
+
+	if _, err := cl.EnumGet(ctx, "nonexistent"); err == nil {
+		t.Error("got nil error for unknown enumeration")
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrIssueNotFound is returned when the referenced issue does not exist.
+	ErrIssueNotFound = errors.New("issue not found")
+	// ErrProjectNotFound is returned when the referenced project does not exist.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrVersionNotFound is returned when the referenced version does not exist.
+	ErrVersionNotFound = errors.New("version not found")
+	// ErrAccessDenied is returned when the user has no right for the operation.
+	ErrAccessDenied = errors.New("access denied")
+	// ErrLoginFailed is returned for bad credentials. It is an ErrAccessDenied, too.
+	ErrLoginFailed = errors.New("login failed")
+	// ErrConflict is returned when the issue has been modified concurrently.
+	ErrConflict = errors.New("conflict")
+	// ErrAttachmentTooLarge is returned when the attachment is larger than the client's MaxAttachmentSize.
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrUnknownEnumValue is returned when the name or ID is not in the enumeration.
+	ErrUnknownEnumValue = errors.New("unknown enum value")
+	// ErrInvalidCustomField is returned when a custom field value does not fit its definition.
+	ErrInvalidCustomField = errors.New("invalid custom field")
+)
+
+// Fault is a SOAP fault returned by the MantisConnect server.
+//
+// Use errors.Is with the Err* sentinels to check for the well-known faults.
+type Fault struct {
+	err error
+	// Method is the called SOAP method.
+	Method string
+	// Code is the faultcode, such as "SOAP-ENV:Client".
+	Code string
+	// String is the human-readable faultstring.
+	String string
+	Actor  string
+	// Detail is the inner XML of the detail element.
+	Detail string
+}
+
+func (f *Fault) Error() string {
+	var buf strings.Builder
+	if f.Method != "" {
+		buf.WriteString(f.Method)
+		buf.WriteString(": ")
+	}
+	buf.WriteString(f.Code)
+	buf.WriteString(": ")
+	buf.WriteString(f.String)
+	return buf.String()
+}
+
+// Unwrap returns the error returned by the SOAP caller.
+func (f *Fault) Unwrap() error { return f.err }
+
+var (
+	rIssueNotFound   = regexp.MustCompile(`(?i)^issue\b.*\b(?:does not exist|not found)`)
+	rProjectNotFound = regexp.MustCompile(`(?i)^project\b.*\b(?:does not exist|not found)`)
+	rVersionNotFound = regexp.MustCompile(`(?i)^version\b.*\b(?:does not exist|not found)`)
+	rAccessDenied    = regexp.MustCompile(`(?i)^access denied\b`)
+	rUnsupported     = regexp.MustCompile(`(?i)^procedure\b.*\bnot present`)
+)
+
+// Is reports whether the fault is the target sentinel, based on the faultstring.
+//
+// A call of an operation unknown to the server is an errors.ErrUnsupported.
+func (f *Fault) Is(target error) bool {
+	s := strings.TrimSpace(f.String)
+	switch target {
+	case ErrIssueNotFound:
+		return rIssueNotFound.MatchString(s)
+	case ErrProjectNotFound:
+		return rProjectNotFound.MatchString(s)
+	case ErrVersionNotFound:
+		return rVersionNotFound.MatchString(s)
+	case ErrAccessDenied:
+		return rAccessDenied.MatchString(s)
+	case errors.ErrUnsupported:
+		return rUnsupported.MatchString(s)
+	case ErrLoginFailed:
+		// Mantis returns a bare "Access denied" for failed logins,
+		// and "Access denied for user ..." for missing rights.
+		return strings.EqualFold(strings.TrimSuffix(s, "."), "access denied")
+	}
+	return false
+}
+
+// IssueErrors are the errors of a bulk operation, by issue ID.
+type IssueErrors map[int]error
+
+func (e IssueErrors) Error() string {
+	ids := slices.Sorted(maps.Keys(e))
+	if len(ids) == 1 {
+		return fmt.Sprintf("issue %d: %v", ids[0], e[ids[0]])
+	}
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d issues failed", len(ids))
+	for i, id := range ids {
+		if i == 0 {
+			buf.WriteString(": ")
+		} else {
+			buf.WriteString("; ")
+		}
+		fmt.Fprintf(&buf, "%d: %v", id, e[id])
+	}
+	return buf.String()
+}
+
+// Unwrap returns the errors, ordered by the issue ID.
+func (e IssueErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, id := range slices.Sorted(maps.Keys(e)) {
+		errs = append(errs, e[id])
+	}
+	return errs
+}
+
+// parseFault finds and decodes the first SOAP Fault in raw.
+func parseFault(raw []byte) *Fault {
+	if i := bytes.IndexByte(raw, '<'); i < 0 {
+		return nil
+	} else {
+		raw = raw[i:]
+	}
+	d := xml.NewDecoder(bytes.NewReader(raw))
+	d.Strict = false
+	d.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil
+		}
+		if st, ok := tok.(xml.StartElement); ok && st.Name.Local == "Fault" {
+			return decodeFault(d, st)
+		}
+	}
+}
+
+// decodeFault decodes the Fault element started by st.
+func decodeFault(d *xml.Decoder, st xml.StartElement) *Fault {
+	var f struct {
+		Code   string `xml:"faultcode"`
+		String string `xml:"faultstring"`
+		Actor  string `xml:"faultactor"`
+		Detail struct {
+			Inner string `xml:",innerxml"`
+		} `xml:"detail"`
+	}
+	if err := d.DecodeElement(&f, &st); err != nil || f.Code == "" && f.String == "" {
+		return nil
+	}
+	return &Fault{
+		Code: strings.TrimSpace(f.Code), String: strings.TrimSpace(f.String),
+		Actor: f.Actor, Detail: strings.TrimSpace(f.Detail.Inner),
+	}
+}
+
+// callError returns a *Fault if any of the raw responses contain one,
+// or the wrapped err otherwise.
+func callError(method string, err error, raws ...[]byte) error {
+	raws = append(raws, []byte(err.Error()))
+	for _, raw := range raws {
+		if f := parseFault(raw); f != nil {
+			f.Method, f.err = method, err
+			return f
+		}
+	}
+	return fmt.Errorf("call %s: %w", method, err)
+}
+
+// faultTransport keeps the body of the failed responses, for decoding the SOAP Fault,
+// if the request's context has a *faultCapture.
+type faultTransport struct {
+	http.RoundTripper
+}
+
+type faultCapture struct {
+	body []byte
+	set  bool
+}
+
+type faultCaptureKey struct{}
+
+// maxFaultSize is the maximum size of the kept response body.
+const maxFaultSize = 1 << 20
+
+func (t faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil || resp.StatusCode < 400 {
+		return resp, err
+	}
+	capture, ok := req.Context().Value(faultCaptureKey{}).(*faultCapture)
+	if !ok || capture.set {
+		return resp, err
+	}
+	// A read error will be returned again when reading the rest of the body.
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxFaultSize))
+	capture.body, capture.set = b, true
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+	return resp, nil
+}
+
+func withFaultCapture(ctx context.Context) (context.Context, *faultCapture) {
+	var capture faultCapture
+	return context.WithValue(ctx, faultCaptureKey{}, &capture), &capture
+}
+
+// vim: set fileencoding=utf-8 noet:
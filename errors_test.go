@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestFault(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+
+	_, err := mantis.NewWithHTTPClient(ctx, &http.Client{}, srv.URL, mantistest.DefaultUser, "s3cr3t-password")
+	if !errors.Is(err, mantis.ErrLoginFailed) || !errors.Is(err, mantis.ErrAccessDenied) {
+		t.Errorf("bad password: got %+v, wanted ErrLoginFailed", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "s3cr3t-password") {
+		t.Errorf("password in error: %q", err.Error())
+	}
+
+	_, err = cl.IssueGet(ctx, 42)
+	var f *mantis.Fault
+	if !errors.As(err, &f) {
+		t.Fatalf("IssueGet: got %#v, wanted *Fault", err)
+	}
+	if f.Method != "mc_issue_get" || f.Code == "" || f.String == "" {
+		t.Errorf("got %#v", f)
+	}
+	if !errors.Is(err, mantis.ErrIssueNotFound) || errors.Is(err, mantis.ErrAccessDenied) {
+		t.Errorf("IssueGet: got %+v, wanted ErrIssueNotFound", err)
+	}
+	if _, err = cl.ProjectVersionsList(ctx, 42); !errors.Is(err, mantis.ErrProjectNotFound) {
+		t.Errorf("ProjectVersionsList: got %+v, wanted ErrProjectNotFound", err)
+	}
+
+	srv.AddUser(mantis.AccountData{Name: "viewer"}, "viewer", mantistest.Viewer)
+	cl = srv.Login(t, nil, "viewer", "viewer")
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	_, err = cl.ProjectVersionAdd(ctx, projectID, "1.0", "", false, false, nil)
+	if !errors.Is(err, mantis.ErrAccessDenied) || errors.Is(err, mantis.ErrLoginFailed) {
+		t.Errorf("ProjectVersionAdd: got %+v, wanted ErrAccessDenied", err)
+	}
+}
@@ -0,0 +1,374 @@
+// Code generated by mantisgen from mantisconnect.wsdl. DO NOT EDIT.
+
+package mantis
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+type VersionRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_version"`
+}
+
+type VersionResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_versionResponse"`
+	Return  string   `xml:"return"`
+}
+
+type IssueGetBiggestIDRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get_biggest_id"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type IssueGetBiggestIDResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get_biggest_idResponse"`
+	Return  int      `xml:"return"`
+}
+
+type IssueGetIDFromSummaryRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get_id_from_summary"`
+	Auth
+	Summary string `xml:"summary"`
+}
+
+type IssueGetIDFromSummaryResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get_id_from_summaryResponse"`
+	Return  int      `xml:"return"`
+}
+
+type IssueDeleteRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_delete"`
+	Auth
+	IssueID IssueID `xml:"issue_id"`
+}
+
+type IssueDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type IssueAttachmentDeleteRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_attachment_delete"`
+	Auth
+	IssueAttachmentID int `xml:"issue_attachment_id"`
+}
+
+type IssueAttachmentDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_attachment_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type ProjectGetIssuesForUserRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_issues_for_user"`
+	Auth
+	ProjectID  int         `xml:"project_id"`
+	FilterType string      `xml:"filter_type"`
+	TargetUser AccountData `xml:"target_user"`
+	PageNumber int         `xml:"page_number"`
+	PerPage    int         `xml:"per_page"`
+}
+
+type ProjectGetIssuesForUserResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_project_get_issues_for_userResponse"`
+	Return  []IssueData `xml:"return>item"`
+}
+
+type ProjectAttachmentData struct {
+	ID            int    `xml:"id,omitempty"`
+	Filename      string `xml:"filename,omitempty"`
+	Title         string `xml:"title,omitempty"`
+	Description   string `xml:"description,omitempty"`
+	Size          int    `xml:"size,omitempty"`
+	ContentType   string `xml:"content_type,omitempty"`
+	DateSubmitted *Time  `xml:"date_submitted,omitempty"`
+	DownloadURL   string `xml:"download_url,omitempty"`
+	UserID        int    `xml:"user_id,omitempty"`
+}
+
+type ProjectGetAttachmentsRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_attachments"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type ProjectGetAttachmentsResponse struct {
+	XMLName xml.Name                `xml:"http://futureware.biz/mantisconnect mc_project_get_attachmentsResponse"`
+	Return  []ProjectAttachmentData `xml:"return>item"`
+}
+
+type ProjectAttachmentGetRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_get"`
+	Auth
+	ProjectAttachmentID int `xml:"project_attachment_id"`
+}
+
+type ProjectAttachmentGetResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_getResponse"`
+	Return  Base64   `xml:"return"`
+}
+
+type ProjectAttachmentDeleteRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_delete"`
+	Auth
+	ProjectAttachmentID int `xml:"project_attachment_id"`
+}
+
+type ProjectAttachmentDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type FilterData struct {
+	ID           int          `xml:"id,omitempty"`
+	Owner        *AccountData `xml:"owner,omitempty"`
+	ProjectID    int          `xml:"project_id,omitempty"`
+	IsPublic     bool         `xml:"is_public,omitempty"`
+	Name         string       `xml:"name,omitempty"`
+	FilterString string       `xml:"filter_string,omitempty"`
+	URL          string       `xml:"url,omitempty"`
+}
+
+type FilterGetRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_filter_get"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type FilterGetResponse struct {
+	XMLName xml.Name     `xml:"http://futureware.biz/mantisconnect mc_filter_getResponse"`
+	Return  []FilterData `xml:"return>item"`
+}
+
+type FilterGetIssuesRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_filter_get_issues"`
+	Auth
+	ProjectID  int `xml:"project_id"`
+	FilterID   int `xml:"filter_id"`
+	PageNumber int `xml:"page_number"`
+	PerPage    int `xml:"per_page"`
+}
+
+type FilterGetIssuesResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_filter_get_issuesResponse"`
+	Return  []IssueData `xml:"return>item"`
+}
+
+type FilterSearchIssuesRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_filter_search_issues"`
+	Auth
+	Filter     FilterSearchData `xml:"filter"`
+	PageNumber int              `xml:"page_number"`
+	PerPage    int              `xml:"per_page"`
+}
+
+type FilterSearchIssuesResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_filter_search_issuesResponse"`
+	Return  []IssueData `xml:"return>item"`
+}
+
+type ConfigGetStringRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_config_get_string"`
+	Auth
+	ConfigVar string `xml:"config_var"`
+}
+
+type ConfigGetStringResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_config_get_stringResponse"`
+	Return  string   `xml:"return"`
+}
+
+type IssueCheckinRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_checkin"`
+	Auth
+	IssueID IssueID `xml:"issue_id"`
+	Comment string  `xml:"comment"`
+	Fixed   bool    `xml:"fixed"`
+}
+
+type IssueCheckinResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_checkinResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type UserPrefGetPrefRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_user_pref_get_pref"`
+	Auth
+	ProjectID int    `xml:"project_id"`
+	PrefName  string `xml:"pref_name"`
+}
+
+type UserPrefGetPrefResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_user_pref_get_prefResponse"`
+	Return  string   `xml:"return"`
+}
+
+type ProfileData struct {
+	ID          int          `xml:"id,omitempty"`
+	UserID      *AccountData `xml:"user_id,omitempty"`
+	Platform    string       `xml:"platform,omitempty"`
+	OS          string       `xml:"os,omitempty"`
+	OSBuild     string       `xml:"os_build,omitempty"`
+	Description string       `xml:"description,omitempty"`
+}
+
+type ProfileDataSearchResult struct {
+	Results      []ProfileData `xml:"results>item,omitempty"`
+	TotalResults int           `xml:"total_results,omitempty"`
+}
+
+type UserProfilesGetAllRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_user_profiles_get_all"`
+	Auth
+	PageNumber int `xml:"page_number"`
+	PerPage    int `xml:"per_page"`
+}
+
+type UserProfilesGetAllResponse struct {
+	XMLName xml.Name                `xml:"http://futureware.biz/mantisconnect mc_user_profiles_get_allResponse"`
+	Return  ProfileDataSearchResult `xml:"return"`
+}
+
+// Version calls mc_version.
+func (c Client) Version(ctx context.Context) (string, error) {
+	var resp VersionResponse
+	err := c.Call(ctx, "mc_version",
+		VersionRequest{},
+		&resp)
+	return resp.Return, err
+}
+
+// IssueGetBiggestID gets the latest submitted issue in the specified project.
+func (c Client) IssueGetBiggestID(ctx context.Context, projectID int) (int, error) {
+	var resp IssueGetBiggestIDResponse
+	err := c.Call(ctx, "mc_issue_get_biggest_id",
+		IssueGetBiggestIDRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// IssueGetIDFromSummary gets the id of the issue with the specified summary.
+func (c Client) IssueGetIDFromSummary(ctx context.Context, summary string) (int, error) {
+	var resp IssueGetIDFromSummaryResponse
+	err := c.Call(ctx, "mc_issue_get_id_from_summary",
+		IssueGetIDFromSummaryRequest{Auth: c.auth, Summary: summary},
+		&resp)
+	return resp.Return, err
+}
+
+// IssueDelete deletes the issue with the specified id.
+func (c Client) IssueDelete(ctx context.Context, issueID int) error {
+	var resp IssueDeleteResponse
+	return c.Call(ctx, "mc_issue_delete",
+		IssueDeleteRequest{Auth: c.auth, IssueID: IssueID(issueID)},
+		&resp)
+}
+
+// IssueAttachmentDelete deletes the issue attachment with the specified id.
+func (c Client) IssueAttachmentDelete(ctx context.Context, issueAttachmentID int) error {
+	var resp IssueAttachmentDeleteResponse
+	return c.Call(ctx, "mc_issue_attachment_delete",
+		IssueAttachmentDeleteRequest{Auth: c.auth, IssueAttachmentID: issueAttachmentID},
+		&resp)
+}
+
+// ProjectGetIssuesForUser gets the issues filtered by the specified user within the specified
+// project. Supported types include "assigned", "monitored", "reported". Pass "-1" for the per_page
+// parameter to get all issues. Use project id "0" for all projects.
+func (c Client) ProjectGetIssuesForUser(ctx context.Context, projectID int, filterType string, targetUser AccountData, pageNumber, perPage int) ([]IssueData, error) {
+	var resp ProjectGetIssuesForUserResponse
+	err := c.Call(ctx, "mc_project_get_issues_for_user",
+		ProjectGetIssuesForUserRequest{Auth: c.auth, ProjectID: projectID, FilterType: filterType, TargetUser: targetUser, PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// ProjectGetAttachments gets the attachments that belong to the specified project.
+func (c Client) ProjectGetAttachments(ctx context.Context, projectID int) ([]ProjectAttachmentData, error) {
+	var resp ProjectGetAttachmentsResponse
+	err := c.Call(ctx, "mc_project_get_attachments",
+		ProjectGetAttachmentsRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// ProjectAttachmentGet gets the data for the specified project attachment.
+func (c Client) ProjectAttachmentGet(ctx context.Context, projectAttachmentID int) (Base64, error) {
+	var resp ProjectAttachmentGetResponse
+	err := c.Call(ctx, "mc_project_attachment_get",
+		ProjectAttachmentGetRequest{Auth: c.auth, ProjectAttachmentID: projectAttachmentID},
+		&resp)
+	return resp.Return, err
+}
+
+// ProjectAttachmentDelete deletes the project attachment with the specified id.
+func (c Client) ProjectAttachmentDelete(ctx context.Context, projectAttachmentID int) error {
+	var resp ProjectAttachmentDeleteResponse
+	return c.Call(ctx, "mc_project_attachment_delete",
+		ProjectAttachmentDeleteRequest{Auth: c.auth, ProjectAttachmentID: projectAttachmentID},
+		&resp)
+}
+
+// FilterGet gets the filters defined for the specified project.
+func (c Client) FilterGet(ctx context.Context, projectID int) ([]FilterData, error) {
+	var resp FilterGetResponse
+	err := c.Call(ctx, "mc_filter_get",
+		FilterGetRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// FilterGetIssues gets the issues that match the specified filter and paging details. Pass "-1" for
+// the per_page parameter to get all issues.
+func (c Client) FilterGetIssues(ctx context.Context, projectID, filterID, pageNumber, perPage int) ([]IssueData, error) {
+	var resp FilterGetIssuesResponse
+	err := c.Call(ctx, "mc_filter_get_issues",
+		FilterGetIssuesRequest{Auth: c.auth, ProjectID: projectID, FilterID: filterID, PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// FilterSearchIssues gets the issues that match the custom filter and paging details.
+func (c Client) FilterSearchIssues(ctx context.Context, filter FilterSearchData, pageNumber, perPage int) ([]IssueData, error) {
+	var resp FilterSearchIssuesResponse
+	err := c.Call(ctx, "mc_filter_search_issues",
+		FilterSearchIssuesRequest{Auth: c.auth, Filter: filter, PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// ConfigGetString gets the value for the specified configuration variable.
+func (c Client) ConfigGetString(ctx context.Context, configVar string) (string, error) {
+	var resp ConfigGetStringResponse
+	err := c.Call(ctx, "mc_config_get_string",
+		ConfigGetStringRequest{Auth: c.auth, ConfigVar: configVar},
+		&resp)
+	return resp.Return, err
+}
+
+// IssueCheckin notifies MantisBT of a check-in for the issue with the specified id.
+func (c Client) IssueCheckin(ctx context.Context, issueID int, comment string, fixed bool) error {
+	var resp IssueCheckinResponse
+	return c.Call(ctx, "mc_issue_checkin",
+		IssueCheckinRequest{Auth: c.auth, IssueID: IssueID(issueID), Comment: comment, Fixed: fixed},
+		&resp)
+}
+
+// UserPrefGetPref gets the value for the specified user preference.
+func (c Client) UserPrefGetPref(ctx context.Context, projectID int, prefName string) (string, error) {
+	var resp UserPrefGetPrefResponse
+	err := c.Call(ctx, "mc_user_pref_get_pref",
+		UserPrefGetPrefRequest{Auth: c.auth, ProjectID: projectID, PrefName: prefName},
+		&resp)
+	return resp.Return, err
+}
+
+// UserProfilesGetAll gets profiles available to the current user.
+func (c Client) UserProfilesGetAll(ctx context.Context, pageNumber, perPage int) (ProfileDataSearchResult, error) {
+	var resp UserProfilesGetAllResponse
+	err := c.Call(ctx, "mc_user_profiles_get_all",
+		UserProfilesGetAllRequest{Auth: c.auth, PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
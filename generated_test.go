@@ -0,0 +1,65 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestGeneratedMethods(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	otherID := srv.AddProject(mantis.ProjectData{Name: "other"}, 0)
+	var ids []int
+	for _, pID := range []int{projectID, projectID, otherID} {
+		ids = append(ids, srv.NewIssue(t, pID, mantis.IssueData{}))
+	}
+
+	if id, err := cl.IssueGetBiggestID(ctx, projectID); err != nil {
+		t.Fatal(err)
+	} else if id != ids[1] {
+		t.Errorf("got %d, wanted %d", id, ids[1])
+	}
+	if err := cl.IssueDelete(ctx, ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := cl.IssueGetBiggestID(ctx, projectID); err != nil {
+		t.Fatal(err)
+	} else if id != ids[0] {
+		t.Errorf("got %d, wanted %d", id, ids[0])
+	}
+	if err := cl.IssueDelete(ctx, ids[1]); !errors.Is(err, mantis.ErrIssueNotFound) {
+		t.Errorf("got %+v, wanted ErrIssueNotFound", err)
+	}
+}
+
+func TestBase64(t *testing.T) {
+	var resp mantis.ProjectAttachmentGetResponse
+	if err := xml.Unmarshal([]byte(`<mc_project_attachment_getResponse xmlns="http://futureware.biz/mantisconnect">
+<return>aGVs
+bG8=</return></mc_project_attachment_getResponse>`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(resp.Return); got != "hello" {
+		t.Errorf("got %q, wanted hello", got)
+	}
+	b, err := xml.Marshal(struct {
+		XMLName xml.Name      `xml:"x"`
+		Content mantis.Base64 `xml:"content"`
+	}{Content: mantis.Base64("hello")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "<x><content>aGVsbG8=</content></x>"; got != want {
+		t.Errorf("got %s, wanted %s", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"iter"
+)
+
+// ProjectIssueHeaders returns the pageNumber-th (1-based) page of the issue headers of the project.
+func (c Client) ProjectIssueHeaders(ctx context.Context, projectID, pageNumber, perPage int) ([]IssueHeaderData, error) {
+	var resp ProjectGetIssueHeadersResponse
+	err := c.Call(ctx, "mc_project_get_issue_headers",
+		ProjectGetIssueHeadersRequest{Auth: c.auth, ProjectID: projectID,
+			PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// FilterIssueHeaders returns the pageNumber-th (1-based) page of the headers of the issues
+// matching the stored filter, in the project.
+func (c Client) FilterIssueHeaders(ctx context.Context, projectID, filterID, pageNumber, perPage int) ([]IssueHeaderData, error) {
+	var resp FilterGetIssueHeadersResponse
+	err := c.Call(ctx, "mc_filter_get_issue_headers",
+		FilterGetIssueHeadersRequest{Auth: c.auth, ProjectID: projectID, FilterID: filterID,
+			PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// FilterSearchIssueHeaders returns the pageNumber-th (1-based) page of the headers of the issues
+// matching the filter.
+func (c Client) FilterSearchIssueHeaders(ctx context.Context, filter FilterSearchData, pageNumber, perPage int) ([]IssueHeaderData, error) {
+	var resp FilterSearchIssueHeadersResponse
+	err := c.Call(ctx, "mc_filter_search_issue_headers",
+		FilterSearchIssueHeadersRequest{Auth: c.auth, Filter: filter,
+			PageNumber: pageNumber, PerPage: perPage},
+		&resp)
+	return resp.Return, err
+}
+
+// IssuesGetHeader returns the headers of the issues. A missing issue fails the whole call.
+func (c Client) IssuesGetHeader(ctx context.Context, issueIDs []int) ([]IssueHeaderData, error) {
+	if len(issueIDs) == 0 {
+		return nil, nil
+	}
+	var resp IssuesGetHeaderResponse
+	err := c.Call(ctx, "mc_issues_get_header",
+		IssuesGetHeaderRequest{Auth: c.auth, IssueIDs: issueIDs},
+		&resp)
+	return resp.Return, err
+}
+
+// AllProjectIssueHeaders iterates over the headers of all the issues of the project,
+// fetching perPage headers at a time.
+func (c Client) AllProjectIssueHeaders(ctx context.Context, projectID, perPage int) iter.Seq2[IssueHeaderData, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]IssueHeaderData, error) {
+			return c.ProjectIssueHeaders(ctx, projectID, page, perPage)
+		},
+		issueHeaderID)
+}
+
+// AllFilterIssueHeaders iterates over the headers of all the issues matching the stored filter,
+// fetching perPage headers at a time.
+func (c Client) AllFilterIssueHeaders(ctx context.Context, projectID, filterID, perPage int) iter.Seq2[IssueHeaderData, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]IssueHeaderData, error) {
+			return c.FilterIssueHeaders(ctx, projectID, filterID, page, perPage)
+		},
+		issueHeaderID)
+}
+
+// AllFilterSearchIssueHeaders iterates over the headers of all the issues matching the filter,
+// fetching perPage headers at a time.
+func (c Client) AllFilterSearchIssueHeaders(ctx context.Context, filter FilterSearchData, perPage int) iter.Seq2[IssueHeaderData, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]IssueHeaderData, error) {
+			return c.FilterSearchIssueHeaders(ctx, filter, page, perPage)
+		},
+		issueHeaderID)
+}
+
+func issueHeaderID(h IssueHeaderData) int { return int(h.ID) }
+
+// vim: set fileencoding=utf-8 noet:
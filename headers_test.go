@@ -0,0 +1,82 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssueHeaders(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	otherID := srv.AddProject(mantis.ProjectData{Name: "other"}, 0)
+	var want []int
+	for i := range 5 {
+		pID := projectID
+		if i == 4 {
+			pID = otherID
+		}
+		id := srv.NewIssue(t, pID, mantis.IssueData{})
+		if pID == projectID {
+			want = append(want, id)
+		}
+	}
+	if _, err := cl.IssueNoteAdd(ctx, want[0], mantis.IssueNoteData{Text: "note"}); err != nil {
+		t.Fatal(err)
+	}
+
+	collect := func(seq iter.Seq2[mantis.IssueHeaderData, error]) []int {
+		t.Helper()
+		var ids []int
+		for h, err := range seq {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, int(h.ID))
+		}
+		slices.Sort(ids)
+		return ids
+	}
+	if got := collect(cl.AllProjectIssueHeaders(ctx, projectID, 3)); !slices.Equal(got, want) {
+		t.Errorf("AllProjectIssueHeaders: got %v, wanted %v", got, want)
+	}
+	filter := mantis.FilterSearchData{ProjectID: []int{projectID}}
+	if got := collect(cl.AllFilterSearchIssueHeaders(ctx, filter, 2)); !slices.Equal(got, want) {
+		t.Errorf("AllFilterSearchIssueHeaders: got %v, wanted %v", got, want)
+	}
+	filterID := srv.AddFilter(filter)
+	if got := collect(cl.AllFilterIssueHeaders(ctx, 0, filterID, 2)); !slices.Equal(got, want) {
+		t.Errorf("AllFilterIssueHeaders: got %v, wanted %v", got, want)
+	}
+	if got := collect(cl.AllFilterIssueHeaders(ctx, otherID, filterID, 2)); len(got) != 1 {
+		t.Errorf("AllFilterIssueHeaders of the other project: got %v", got)
+	}
+	if _, err := cl.FilterIssueHeaders(ctx, 0, filterID+1, 1, 10); err == nil {
+		t.Error("got nil error for a nonexistent filter")
+	}
+
+	headers, err := cl.IssuesGetHeader(ctx, want[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2 {
+		t.Fatalf("got %d headers, wanted 2", len(headers))
+	}
+	h := headers[0]
+	if int(h.ID) != want[0] || h.Project != projectID || h.Summary != "summary" ||
+		h.Status != 10 || h.Reporter != cl.User.ID || h.NotesCount != 1 || h.LastUpdated.IsZero() {
+		t.Errorf("got %+v", h)
+	}
+	if _, err = cl.IssuesGetHeader(ctx, []int{want[0], 9999}); err == nil {
+		t.Error("got nil error for a nonexistent issue")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryType is the type of an issue history entry.
+type HistoryType int
+
+// The history types of Mantis (NORMAL_TYPE, NEW_BUG, BUGNOTE_ADDED, ...).
+const (
+	HistoryFieldChanged            = HistoryType(0)
+	HistoryIssueCreated            = HistoryType(1)
+	HistoryNoteAdded               = HistoryType(2)
+	HistoryNoteUpdated             = HistoryType(3)
+	HistoryNoteDeleted             = HistoryType(4)
+	HistoryDescriptionUpdated      = HistoryType(6)
+	HistoryAdditionalInfoUpdated   = HistoryType(7)
+	HistoryStepsToReproduceUpdated = HistoryType(8)
+	HistoryFileAdded               = HistoryType(9)
+	HistoryFileDeleted             = HistoryType(10)
+	HistoryNoteStateChanged        = HistoryType(11)
+	HistoryMonitorAdded            = HistoryType(12)
+	HistoryMonitorDeleted          = HistoryType(13)
+	HistoryRelationshipAdded       = HistoryType(18)
+	HistoryRelationshipDeleted     = HistoryType(19)
+	HistoryClonedTo                = HistoryType(20)
+	HistoryCreatedFrom             = HistoryType(21)
+	HistoryRelationshipReplaced    = HistoryType(23)
+	HistoryTagAttached             = HistoryType(25)
+	HistoryTagDetached             = HistoryType(26)
+	HistoryTagRenamed              = HistoryType(27)
+)
+
+var historyNames = map[HistoryType]string{
+	HistoryFieldChanged:            "field changed",
+	HistoryIssueCreated:            "issue created",
+	HistoryNoteAdded:               "note added",
+	HistoryNoteUpdated:             "note edited",
+	HistoryNoteDeleted:             "note deleted",
+	HistoryDescriptionUpdated:      "description updated",
+	HistoryAdditionalInfoUpdated:   "additional information updated",
+	HistoryStepsToReproduceUpdated: "steps to reproduce updated",
+	HistoryFileAdded:               "file added",
+	HistoryFileDeleted:             "file deleted",
+	HistoryNoteStateChanged:        "note view state changed",
+	HistoryMonitorAdded:            "monitor added",
+	HistoryMonitorDeleted:          "monitor deleted",
+	HistoryRelationshipAdded:       "relationship added",
+	HistoryRelationshipDeleted:     "relationship deleted",
+	HistoryClonedTo:                "cloned to",
+	HistoryCreatedFrom:             "created from",
+	HistoryRelationshipReplaced:    "relationship replaced",
+	HistoryTagAttached:             "tag attached",
+	HistoryTagDetached:             "tag detached",
+	HistoryTagRenamed:              "tag renamed",
+}
+
+func (t HistoryType) String() string {
+	if s, ok := historyNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("HistoryType(%d)", int(t))
+}
+
+// Time returns the time of the change.
+func (h HistoryData) Time() time.Time { return time.Unix(h.Date, 0) }
+
+// IssueGetHistory returns the history of the issue, in chronological order.
+func (c Client) IssueGetHistory(ctx context.Context, issueID int) ([]HistoryData, error) {
+	var resp IssueGetHistoryResponse
+	if err := c.Call(ctx, "mc_issue_get_history",
+		IssueGetHistoryRequest{Auth: c.auth, IssueID: IssueID(issueID)},
+		&resp,
+	); err != nil {
+		return nil, err
+	}
+	return resp.Return, nil
+}
+
+// TimelineEntry is an event of the issue's life: exactly one of History, Note and Attachment is set.
+type TimelineEntry struct {
+	Time       time.Time       `json:"time"`
+	User       string          `json:"user,omitempty"`
+	History    *HistoryData    `json:"history,omitempty"`
+	Note       *NoteData       `json:"note,omitempty"`
+	Attachment *AttachmentData `json:"attachment,omitempty"`
+}
+
+// String returns a one-line description of the event.
+func (e TimelineEntry) String() string {
+	switch {
+	case e.Note != nil:
+		text, _, _ := strings.Cut(strings.TrimSpace(e.Note.Text), "\n")
+		return fmt.Sprintf("note %d: %s", e.Note.ID, text)
+	case e.Attachment != nil:
+		return fmt.Sprintf("attached %s (%d bytes)", e.Attachment.FileName, e.Attachment.Size)
+	case e.History != nil:
+		h := e.History
+		switch h.Type {
+		case HistoryFieldChanged:
+			return fmt.Sprintf("%s: %q → %q", h.Field, h.OldValue, h.NewValue)
+		case HistoryIssueCreated:
+			return h.Type.String()
+		}
+		s := h.Type.String()
+		for _, v := range []string{h.OldValue, h.NewValue} {
+			if v != "" {
+				s += " " + v
+			}
+		}
+		return s
+	}
+	return ""
+}
+
+// IssueTimeline returns the history, the notes and the attachments of the issue
+// merged into one chronological timeline.
+//
+// The "note added" and "file added" history entries are replaced by the
+// notes and attachments themselves, if those still exist.
+func (c Client) IssueTimeline(ctx context.Context, issueID int) ([]TimelineEntry, error) {
+	issue, err := c.IssueGet(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := c.IssueGetHistory(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	noteIDs := make(map[int]struct{}, len(issue.Notes))
+	for _, n := range issue.Notes {
+		noteIDs[n.ID] = struct{}{}
+	}
+	fileNames := make(map[string]struct{}, len(issue.Attachments))
+	for _, a := range issue.Attachments {
+		fileNames[a.FileName] = struct{}{}
+	}
+	usernames := make(map[int]string)
+	entries := make([]TimelineEntry, 0, len(history)+len(issue.Notes)+len(issue.Attachments))
+	for i, h := range history {
+		usernames[h.UserID] = h.Username
+		switch h.Type {
+		case HistoryNoteAdded:
+			if id, err := strconv.Atoi(h.OldValue); err == nil {
+				if _, ok := noteIDs[id]; ok {
+					continue
+				}
+			}
+		case HistoryFileAdded:
+			if _, ok := fileNames[h.OldValue]; ok {
+				continue
+			}
+		}
+		entries = append(entries, TimelineEntry{Time: h.Time(), User: h.Username, History: &history[i]})
+	}
+	for i, n := range issue.Notes {
+		entries = append(entries, TimelineEntry{
+			Time: time.Time(n.DateSubmitted), User: n.Reporter.Name, Note: &issue.Notes[i]})
+	}
+	for i, a := range issue.Attachments {
+		user, ok := usernames[a.UserID]
+		if !ok && a.UserID != 0 {
+			user = "#" + strconv.Itoa(a.UserID)
+		}
+		entries = append(entries, TimelineEntry{
+			Time: time.Time(a.DateSubmitted), User: user, Attachment: &issue.Attachments[i]})
+	}
+	// The history's resolution is seconds.
+	slices.SortStableFunc(entries, func(a, b TimelineEntry) int {
+		return cmp.Compare(a.Time.Unix(), b.Time.Unix())
+	})
+	return entries, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
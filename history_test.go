@@ -0,0 +1,70 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssueTimeline(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv.Now = func() time.Time { now = now.Add(time.Minute); return now }
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{})
+	if _, err := cl.IssueNoteAdd(ctx, issueID, mantis.IssueNoteData{Text: "a note\nsecond line"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.IssueUpdate(ctx, issueID, mantis.IssueData{
+		Status: &mantis.ObjectRef{ID: 50}, Handler: &cl.User,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.IssueAttachmentAdd(ctx, issueID, "a.txt", "text/plain", strings.NewReader("a")); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := cl.IssueGetHistory(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 5 || history[0].Type != mantis.HistoryIssueCreated ||
+		history[0].Username != mantistest.DefaultUser || history[0].Time().IsZero() {
+		t.Fatalf("got history %+v", history)
+	}
+
+	timeline, err := cl.IssueTimeline(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"issue created",
+		"note 1: a note",
+		`status: "10" → "50"`,
+		`handler_id: "0" → "1"`,
+		"attached a.txt (1 bytes)",
+	}
+	if len(timeline) != len(want) {
+		t.Fatalf("got %+v", timeline)
+	}
+	for i, e := range timeline {
+		if got := e.String(); got != want[i] {
+			t.Errorf("%d. got %q, wanted %q", i, got, want[i])
+		}
+		if e.User != mantistest.DefaultUser {
+			t.Errorf("%d. got user %q", i, e.User)
+		}
+		if i > 0 && e.Time.Before(timeline[i-1].Time) {
+			t.Errorf("%d. %s is before %s", i, e.Time, timeline[i-1].Time)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// DefaultConcurrency is the number of concurrent calls of the bulk operations,
+// for non-positive Client.Concurrency.
+const DefaultConcurrency = 4
+
+// IssuesGet returns the issues, in the order of issueIDs, fetching DefaultPerPage issues
+// at a time with mc_issues_get.
+//
+// As mc_issues_get fails as a whole for a single missing issue, the issues of such a batch
+// are fetched one by one with IssueGet, with at most Concurrency calls at a time;
+// as are all the issues, if the server does not support mc_issues_get.
+// Any other error of mc_issues_get is returned as is.
+//
+// The issues which could not be fetched are left out, and their errors are returned
+// in an IssueErrors.
+func (c Client) IssuesGet(ctx context.Context, issueIDs []int) ([]IssueData, error) {
+	ids := make([]int, 0, len(issueIDs))
+	seen := make(map[int]struct{}, len(issueIDs))
+	for _, id := range issueIDs {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	found := make(map[int]IssueData, len(ids))
+	errs := make(IssueErrors)
+	var rest []int
+	var unsupported bool
+	for batch := range slices.Chunk(ids, DefaultPerPage) {
+		if unsupported {
+			rest = append(rest, batch...)
+			continue
+		}
+		var resp IssuesGetResponse
+		if err := c.Call(ctx, "mc_issues_get",
+			IssuesGetRequest{Auth: c.auth, IssueIDs: batch},
+			&resp,
+		); err != nil {
+			if unsupported = errors.Is(err, errors.ErrUnsupported); !unsupported && !errors.Is(err, ErrIssueNotFound) {
+				return nil, err
+			}
+			rest = append(rest, batch...)
+			continue
+		}
+		for _, issue := range resp.Return {
+			if issue.ID != nil {
+				found[int(*issue.ID)] = issue
+			}
+		}
+		for _, id := range batch {
+			if _, ok := found[id]; !ok {
+				errs[id] = ErrIssueNotFound
+			}
+		}
+	}
+	c.issueGetEach(ctx, rest, found, errs)
+
+	issues := make([]IssueData, 0, len(found))
+	for _, id := range ids {
+		if issue, ok := found[id]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	if len(errs) == 0 {
+		return issues, nil
+	}
+	return issues, errs
+}
+
+// issueGetEach fetches the issues with IssueGet, with at most Concurrency calls at a time,
+// storing the issues in found and the errors in errs.
+func (c Client) issueGetEach(ctx context.Context, ids []int, found map[int]IssueData, errs IssueErrors) {
+	if len(ids) == 0 {
+		return
+	}
+	n := c.Concurrency
+	if n <= 0 {
+		n = DefaultConcurrency
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ch := make(chan int)
+	for range min(n, len(ids)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ch {
+				issue, err := c.IssueGet(ctx, id)
+				mu.Lock()
+				if err != nil {
+					errs[id] = err
+				} else {
+					found[id] = issue
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, id := range ids {
+		ch <- id
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,80 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssuesGet(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	var ids []int
+	for range 3 {
+		ids = append(ids, srv.NewIssue(t, projectID, mantis.IssueData{}))
+	}
+	tr := &countingTransport{RoundTripper: http.DefaultTransport}
+	cl := srv.Login(t, &http.Client{Transport: tr}, mantistest.DefaultUser, mantistest.DefaultPassword)
+	issueIDs := func(issues []mantis.IssueData) []int {
+		got := make([]int, len(issues))
+		for i, issue := range issues {
+			got[i] = int(*issue.ID)
+		}
+		return got
+	}
+
+	n := tr.n.Load()
+	issues, err := cl.IssuesGet(ctx, []int{ids[2], ids[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := issueIDs(issues); !slices.Equal(got, []int{ids[2], ids[0]}) {
+		t.Errorf("got %v, wanted %v", got, []int{ids[2], ids[0]})
+	}
+	if got := tr.n.Load() - n; got != 1 {
+		t.Errorf("got %d calls, wanted 1", got)
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err = cl.IssuesGet(cancelCtx, ids); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: got %+v, wanted context.Canceled", err)
+	} else if errors.As(err, new(mantis.IssueErrors)) {
+		t.Errorf("canceled: got %+v, wanted the error of mc_issues_get", err)
+	}
+
+	const missing = 9999
+	for _, disabled := range []bool{false, true} {
+		if disabled {
+			srv.Disable("mc_issues_get")
+			cl.Concurrency = 2
+		}
+		issues, err = cl.IssuesGet(ctx, []int{ids[0], missing, ids[1], ids[0], ids[2]})
+		if got := issueIDs(issues); !slices.Equal(got, ids) {
+			t.Errorf("disabled=%t: got %v, wanted %v", disabled, got, ids)
+		}
+		var errs mantis.IssueErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("disabled=%t: got %#v, wanted IssueErrors", disabled, err)
+		}
+		if len(errs) != 1 || !errors.Is(errs[missing], mantis.ErrIssueNotFound) || !errors.Is(err, mantis.ErrIssueNotFound) {
+			t.Errorf("disabled=%t: got %+v", disabled, errs)
+		}
+	}
+
+	var resp mantis.IssuesGetResponse
+	err = cl.Call(ctx, "mc_issues_get", mantis.IssuesGetRequest{IssueIDs: ids}, &resp)
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("disabled mc_issues_get: got %+v, wanted ErrUnsupported", err)
+	}
+}
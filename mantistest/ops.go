@@ -0,0 +1,1497 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantistest
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+// handlers of the SOAP operations, by operation name.
+var handlers map[string]handlerFunc
+
+func init() {
+	handlers = map[string]handlerFunc{
+		"mc_login": handle(func(s *Server, u *user, req mantis.LoginRequest) (any, error) {
+			return ns1("UserData", mantis.UserData{
+				Account: u.AccountData, AccessLevel: u.accessLevel, Timezone: "UTC",
+			}), nil
+		}),
+
+		"mc_user_token_create": handle(func(s *Server, u *user, req mantis.UserTokenCreateRequest) (any, error) {
+			if _, ok := u.tokens[req.TokenName]; ok {
+				return nil, clientFault("Token name '%s' already used.", req.TokenName)
+			}
+			return xsdString(s.createToken(u, req.TokenName)), nil
+		}),
+
+		"mc_enum_status":              enumHandler[mantis.StatusEnumRequest]("status"),
+		"mc_enum_priorities":          enumHandler[mantis.PriorityEnumRequest]("priority"),
+		"mc_enum_severities":          enumHandler[mantis.SeverityEnumRequest]("severity"),
+		"mc_enum_reproducibilities":   enumHandler[mantis.ReproducibilityEnumRequest]("reproducibility"),
+		"mc_enum_projections":         enumHandler[mantis.ProjectionEnumRequest]("projection"),
+		"mc_enum_etas":                enumHandler[mantis.ETAEnumRequest]("eta"),
+		"mc_enum_resolutions":         enumHandler[mantis.ResolutionEnumRequest]("resolution"),
+		"mc_enum_access_levels":       enumHandler[mantis.AccessLevelEnumRequest]("access_levels"),
+		"mc_enum_project_status":      enumHandler[mantis.ProjectStatusEnumRequest]("project_status"),
+		"mc_enum_project_view_states": enumHandler[mantis.ProjectViewStateEnumRequest]("project_view_state"),
+		"mc_enum_view_states":         enumHandler[mantis.ViewStateEnumRequest]("view_state"),
+		"mc_enum_custom_field_types":  enumHandler[mantis.CustomFieldTypeEnumRequest]("custom_field_type"),
+		"mc_enum_get": handle(func(s *Server, u *user, req mantis.EnumGetRequest) (any, error) {
+			values, ok := s.enums[req.Enumeration]
+			if !ok {
+				return nil, clientFault("Config option '%s_enum_string' not found.", req.Enumeration)
+			}
+			parts := make([]string, len(values))
+			for i, v := range values {
+				parts[i] = strconv.Itoa(v.ID) + ":" + v.Name
+			}
+			return xsdString(strings.Join(parts, ",")), nil
+		}),
+
+		"mc_issue_exists": handle(func(s *Server, u *user, req mantis.IssueExistsRequest) (any, error) {
+			_, ok := s.issues[int(req.IssueID)]
+			return xsdBoolean(ok), nil
+		}),
+
+		"mc_issue_get": handle(func(s *Server, u *user, req mantis.IssueGetRequest) (any, error) {
+			issue, err := s.issue(int(req.IssueID))
+			if err != nil {
+				return nil, err
+			}
+			return ns1("IssueData", issue), nil
+		}),
+
+		"mc_issue_get_history": handle(func(s *Server, u *user, req mantis.IssueGetHistoryRequest) (any, error) {
+			if _, err := s.issue(int(req.IssueID)); err != nil {
+				return nil, err
+			}
+			return arrayOf("ns1:HistoryData", s.history[int(req.IssueID)]), nil
+		}),
+
+		"mc_issue_add": handle(func(s *Server, u *user, req mantis.IssueAddRequest) (any, error) {
+			if u.accessLevel < Reporter {
+				return nil, accessDenied(u)
+			}
+			id, err := s.addIssue(u, req.Issue)
+			return xsdInteger(id), err
+		}),
+
+		"mc_issue_update": handle(func(s *Server, u *user, req mantis.IssueUpdateRequest) (any, error) {
+			if u.accessLevel < Updater {
+				return nil, accessDenied(u)
+			}
+			return xsdBoolean(true), s.updateIssue(u, int(req.IssueID), req.Issue)
+		}),
+
+		"mc_issue_delete": handle(func(s *Server, u *user, req mantis.IssueDeleteRequest) (any, error) {
+			if u.accessLevel < Developer {
+				return nil, accessDenied(u)
+			}
+			if _, err := s.issue(int(req.IssueID)); err != nil {
+				return nil, err
+			}
+			delete(s.issues, int(req.IssueID))
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_issue_get_biggest_id": handle(func(s *Server, u *user, req mantis.IssueGetBiggestIDRequest) (any, error) {
+			if req.ProjectID != 0 {
+				if _, err := s.project(req.ProjectID); err != nil {
+					return nil, err
+				}
+			}
+			var biggest int
+			for id, issue := range s.issues {
+				if (req.ProjectID == 0 || issue.Project != nil && issue.Project.ID == req.ProjectID) && id > biggest {
+					biggest = id
+				}
+			}
+			return xsdInteger(biggest), nil
+		}),
+
+		"mc_issue_note_add": handle(func(s *Server, u *user, req mantis.IssueNoteAddRequest) (any, error) {
+			if u.accessLevel < Reporter {
+				return nil, accessDenied(u)
+			}
+			id, err := s.addNote(u, int(req.IssueID), req.Note)
+			return xsdInteger(id), err
+		}),
+
+		"mc_issue_note_update": handle(func(s *Server, u *user, req mantis.IssueNoteUpdateRequest) (any, error) {
+			if req.Note.ID == nil {
+				return nil, clientFault("Issue note id must not be blank.")
+			}
+			issue, note, err := s.ownNote(u, *req.Note.ID)
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(req.Note.Text) == "" {
+				return nil, clientFault("Issue note text must not be blank.")
+			}
+			if req.Note.ViewState != nil {
+				vs, err := s.enumRef("view_state", req.Note.ViewState, 10)
+				if err != nil {
+					return nil, err
+				}
+				if vs.ID != note.ViewState.ID {
+					s.addHistory(u, int(*issue.ID), mantis.HistoryNoteStateChanged, "", fmt.Sprintf("%07d", note.ID), "")
+				}
+				note.ViewState = vs
+			}
+			note.Text = req.Note.Text
+			now := mantis.Time(s.now())
+			note.LastModified = now
+			issue.LastUpdated = &now
+			s.addHistory(u, int(*issue.ID), mantis.HistoryNoteUpdated, "", fmt.Sprintf("%07d", note.ID), "")
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_issue_note_delete": handle(func(s *Server, u *user, req mantis.IssueNoteDeleteRequest) (any, error) {
+			issue, note, err := s.ownNote(u, req.IssueNoteID)
+			if err != nil {
+				return nil, err
+			}
+			noteID := note.ID
+			issue.Notes = slices.DeleteFunc(slices.Clone(issue.Notes), func(n mantis.NoteData) bool { return n.ID == noteID })
+			now := mantis.Time(s.now())
+			issue.LastUpdated = &now
+			s.addHistory(u, int(*issue.ID), mantis.HistoryNoteDeleted, "", fmt.Sprintf("%07d", noteID), "")
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_issue_attachment_add": handle(func(s *Server, u *user, req issueAttachmentAddRequest) (any, error) {
+			if u.accessLevel < Reporter {
+				return nil, accessDenied(u)
+			}
+			content, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(req.Content), ""))
+			if err != nil {
+				return nil, clientFault("Invalid content: %v", err)
+			}
+			id, err := s.addAttachment(u, req.IssueID, req.Name, req.FileType, content)
+			return xsdInteger(id), err
+		}),
+
+		"mc_issue_relationship_add": handle(func(s *Server, u *user, req mantis.IssueRelationshipAddRequest) (any, error) {
+			if u.accessLevel < Updater {
+				return nil, accessDenied(u)
+			}
+			id, err := s.addRelationship(u, int(req.IssueID), req.Relationship.Type.ID, req.Relationship.TargetID)
+			return xsdInteger(id), err
+		}),
+
+		"mc_issue_relationship_delete": handle(func(s *Server, u *user, req mantis.IssueRelationshipDeleteRequest) (any, error) {
+			if u.accessLevel < Updater {
+				return nil, accessDenied(u)
+			}
+			return xsdBoolean(true), s.deleteRelationship(u, int(req.IssueID), req.RelationshipID)
+		}),
+
+		"mc_issue_set_tags": handle(func(s *Server, u *user, req mantis.IssueSetTagsRequest) (any, error) {
+			if u.accessLevel < Reporter {
+				return nil, accessDenied(u)
+			}
+			return xsdBoolean(true), s.setTags(u, int(req.IssueID), req.Tags)
+		}),
+
+		"mc_tag_get_all": handle(func(s *Server, u *user, req mantis.TagGetAllRequest) (any, error) {
+			tags := make([]mantis.TagData, 0, len(s.tags))
+			for _, t := range s.tags {
+				tags = append(tags, *t)
+			}
+			slices.SortFunc(tags, func(a, b mantis.TagData) int { return cmp.Compare(a.Name, b.Name) })
+			return ns1("TagDataSearchResult", tagDataSearchResult{
+				Results:      arrayOf("ns1:TagData", paginate(tags, req.PageNumber, req.PerPage)),
+				TotalResults: xsdInteger(len(tags)),
+			}), nil
+		}),
+
+		"mc_tag_add": handle(func(s *Server, u *user, req mantis.TagAddRequest) (any, error) {
+			if u.accessLevel < Reporter {
+				return nil, accessDenied(u)
+			}
+			id, err := s.addTag(u, req.Tag.Name, req.Tag.Description)
+			return xsdInteger(id), err
+		}),
+
+		"mc_tag_delete": handle(func(s *Server, u *user, req mantis.TagDeleteRequest) (any, error) {
+			if u.accessLevel < Developer {
+				return nil, accessDenied(u)
+			}
+			if _, ok := s.tags[req.TagID]; !ok {
+				return nil, clientFault("Tag '%d' does not exist.", req.TagID)
+			}
+			delete(s.tags, req.TagID)
+			for _, issue := range s.issues {
+				issue.Tags = slices.DeleteFunc(slices.Clone(issue.Tags),
+					func(t mantis.ObjectRef) bool { return t.ID == req.TagID })
+			}
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_issue_attachment_get": handle(func(s *Server, u *user, req mantis.IssueAttachmentGetRequest) (any, error) {
+			a, ok := s.attachments[req.IssueAttachmentID]
+			if !ok || a.issueID == 0 {
+				return nil, clientFault("Unable to find an attachment with type bug and id %d.", req.IssueAttachmentID)
+			}
+			// Split into lines, to exercise the whitespace handling of the clients.
+			b64 := base64.StdEncoding.EncodeToString(a.content)
+			var buf strings.Builder
+			for len(b64) > 76 {
+				buf.WriteString(b64[:76])
+				buf.WriteString("\r\n")
+				b64 = b64[76:]
+			}
+			buf.WriteString(b64)
+			return typed{Value: buf.String(), Type: "xsd:base64Binary"}, nil
+		}),
+
+		"mc_project_attachment_add": handle(func(s *Server, u *user, req projectAttachmentAddRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			if req.Name == "" {
+				return nil, clientFault("Mandatory field 'name' is missing.")
+			}
+			content, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(req.Content), ""))
+			if err != nil {
+				return nil, clientFault("Invalid content: %v", err)
+			}
+			id := s.nextID("attachment")
+			s.attachments[id] = &attachment{
+				AttachmentData: mantis.AttachmentData{
+					ID: id, FileName: req.Name, Size: len(content), ContentType: req.FileType,
+					DateSubmitted: mantis.Time(s.now()),
+					DownloadURL:   fmt.Sprintf("%s/file_download.php?file_id=%d&type=doc", s.URL, id),
+					UserID:        u.ID,
+				},
+				projectID: req.ProjectID, content: content,
+			}
+			return xsdInteger(id), nil
+		}),
+
+		"mc_filter_search_issue_ids": handle(func(s *Server, u *user, req mantis.FilterSearchIssueIDsRequest) (any, error) {
+			issues := s.search(searchFilter(req.Filter))
+			ids := make([]int, 0, len(issues))
+			for _, issue := range paginate(issues, req.PageNumber, req.PerPage) {
+				ids = append(ids, int(*issue.ID))
+			}
+			return arrayOf("xsd:integer", ids), nil
+		}),
+
+		"mc_project_get_issues": handle(func(s *Server, u *user, req mantis.ProjectIssuesRequest) (any, error) {
+			var filter mantis.FilterSearchData
+			if req.ProjectID != 0 {
+				if _, err := s.project(req.ProjectID); err != nil {
+					return nil, err
+				}
+				filter.ProjectID = []int{req.ProjectID}
+			}
+			issues := paginate(s.search(filter), req.PageNumber, req.PerPage)
+			result := make([]mantis.IssueData, len(issues))
+			for i, issue := range issues {
+				result[i] = cloneIssue(issue)
+			}
+			return arrayOf("ns1:IssueData", result), nil
+		}),
+
+		"mc_project_get_issue_headers": handle(func(s *Server, u *user, req mantis.ProjectGetIssueHeadersRequest) (any, error) {
+			var filter mantis.FilterSearchData
+			if req.ProjectID != 0 {
+				if _, err := s.project(req.ProjectID); err != nil {
+					return nil, err
+				}
+				filter.ProjectID = []int{req.ProjectID}
+			}
+			return arrayOf("ns1:IssueHeaderData", issueHeaders(paginate(s.search(filter), req.PageNumber, req.PerPage))), nil
+		}),
+
+		"mc_filter_get_issue_headers": handle(func(s *Server, u *user, req mantis.FilterGetIssueHeadersRequest) (any, error) {
+			stored, ok := s.filters[req.FilterID]
+			if !ok {
+				return nil, clientFault("Filter '%d' does not exist.", req.FilterID)
+			}
+			filter := *stored
+			if req.ProjectID != 0 {
+				if _, err := s.project(req.ProjectID); err != nil {
+					return nil, err
+				}
+				filter.ProjectID = []int{req.ProjectID}
+			}
+			return arrayOf("ns1:IssueHeaderData", issueHeaders(paginate(s.search(filter), req.PageNumber, req.PerPage))), nil
+		}),
+
+		"mc_filter_search_issue_headers": handle(func(s *Server, u *user, req mantis.FilterSearchIssueHeadersRequest) (any, error) {
+			return arrayOf("ns1:IssueHeaderData", issueHeaders(paginate(s.search(searchFilter(req.Filter)), req.PageNumber, req.PerPage))), nil
+		}),
+
+		"mc_issues_get": handle(func(s *Server, u *user, req mantis.IssuesGetRequest) (any, error) {
+			issues := make([]mantis.IssueData, 0, len(req.IssueIDs))
+			for _, id := range req.IssueIDs {
+				issue, err := s.issue(id)
+				if err != nil {
+					return nil, err
+				}
+				issues = append(issues, issue)
+			}
+			return arrayOf("ns1:IssueData", issues), nil
+		}),
+
+		"mc_issues_get_header": handle(func(s *Server, u *user, req mantis.IssuesGetHeaderRequest) (any, error) {
+			issues := make([]*mantis.IssueData, 0, len(req.IssueIDs))
+			for _, id := range req.IssueIDs {
+				issue, ok := s.issues[id]
+				if !ok {
+					return nil, clientFault("Issue '%d' does not exist.", id)
+				}
+				issues = append(issues, issue)
+			}
+			return arrayOf("ns1:IssueHeaderData", issueHeaders(issues)), nil
+		}),
+
+		"mc_project_get_users": handle(func(s *Server, u *user, req mantis.ProjectGetUsersRequest) (any, error) {
+			if _, err := s.project(req.ProjectID); err != nil && req.ProjectID != 0 {
+				return nil, err
+			}
+			users := make([]mantis.AccountData, 0, len(s.users))
+			for _, u := range s.users {
+				if u.accessLevel >= req.Access {
+					users = append(users, u.AccountData)
+				}
+			}
+			slices.SortFunc(users, func(a, b mantis.AccountData) int { return cmp.Compare(a.ID, b.ID) })
+			return arrayOf("ns1:AccountData", users), nil
+		}),
+
+		"mc_projects_get_user_accessible": handle(func(s *Server, u *user, req mantis.ProjectsGetUserAccessibleRequest) (any, error) {
+			return arrayOf("ns1:ProjectData", s.subprojects(0)), nil
+		}),
+
+		"mc_project_add": handle(func(s *Server, u *user, req mantis.ProjectAddRequest) (any, error) {
+			if u.accessLevel < Admin {
+				return nil, accessDenied(u)
+			}
+			p := &project{}
+			if err := s.setProject(p, req.Project); err != nil {
+				return nil, err
+			}
+			p.ID = s.nextID("project")
+			s.projects[p.ID] = p
+			return xsdInteger(p.ID), nil
+		}),
+
+		"mc_project_update": handle(func(s *Server, u *user, req mantis.ProjectUpdateRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			return xsdBoolean(true), s.setProject(p, req.Project)
+		}),
+
+		"mc_project_delete": handle(func(s *Server, u *user, req mantis.ProjectDeleteRequest) (any, error) {
+			if u.accessLevel < Admin {
+				return nil, accessDenied(u)
+			}
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			for id, issue := range s.issues {
+				if issue.Project.ID == req.ProjectID {
+					delete(s.issues, id)
+					delete(s.history, id)
+				}
+			}
+			for id, v := range s.versions {
+				if v.ProjectID == req.ProjectID {
+					delete(s.versions, id)
+				}
+			}
+			for id, a := range s.attachments {
+				if a.projectID == req.ProjectID || (a.issueID != 0 && s.issues[a.issueID] == nil) {
+					delete(s.attachments, id)
+				}
+			}
+			// The subprojects become top-level projects.
+			for _, p := range s.projects {
+				if p.parentID == req.ProjectID {
+					p.parentID = 0
+				}
+			}
+			delete(s.projects, req.ProjectID)
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_project_get_all_subprojects": handle(func(s *Server, u *user, req mantis.ProjectGetAllSubprojectsRequest) (any, error) {
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			var ids []string
+			for _, id := range s.projectIDs(req.ProjectID)[1:] {
+				ids = append(ids, strconv.Itoa(id))
+			}
+			return arrayOf("xsd:string", ids), nil
+		}),
+
+		"mc_project_get_id_from_name": handle(func(s *Server, u *user, req mantis.ProjectGetIDFromNameRequest) (any, error) {
+			for _, p := range s.projects {
+				if p.Name == req.ProjectName {
+					return xsdInteger(p.ID), nil
+				}
+			}
+			return xsdInteger(0), nil
+		}),
+
+		"mc_project_get_categories": handle(func(s *Server, u *user, req mantis.ProjectCategoriesReq) (any, error) {
+			if req.ProjectID == mantis.AllProjects {
+				return arrayOf("xsd:string", slices.Clone(s.categories)), nil
+			}
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			return arrayOf("xsd:string", s.projectCategories(p)), nil
+		}),
+
+		"mc_project_add_category": handle(func(s *Server, u *user, req mantis.ProjectAddCategoryRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(req.CategoryName) == "" {
+				return nil, clientFault("Category name must not be blank.")
+			}
+			if slices.Contains(p.categories, req.CategoryName) {
+				return nil, clientFault("A category with that name already exists.")
+			}
+			p.categories = append(p.categories, req.CategoryName)
+			return xsdInteger(s.nextID("category")), nil
+		}),
+
+		"mc_project_delete_category": handle(func(s *Server, u *user, req mantis.ProjectDeleteCategoryRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			i := slices.Index(p.categories, req.CategoryName)
+			if i < 0 {
+				return nil, clientFault("Category '%s' not found for project '%d'.", req.CategoryName, req.ProjectID)
+			}
+			p.categories = slices.Delete(slices.Clone(p.categories), i, i+1)
+			s.renameCategory(req.ProjectID, req.CategoryName, "")
+			return xsdInteger(1), nil
+		}),
+
+		"mc_project_rename_category_by_name": handle(func(s *Server, u *user, req mantis.ProjectRenameCategoryByNameRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(req.CategoryNameNew) == "" {
+				return nil, clientFault("Category name must not be blank.")
+			}
+			i := slices.Index(p.categories, req.CategoryName)
+			if i < 0 {
+				return nil, clientFault("Category '%s' not found for project '%d'.", req.CategoryName, req.ProjectID)
+			}
+			if req.CategoryNameNew != req.CategoryName && slices.Contains(p.categories, req.CategoryNameNew) {
+				return nil, clientFault("A category with that name already exists.")
+			}
+			if req.AssignedTo != 0 && s.users[req.AssignedTo] == nil {
+				return nil, clientFault("User '%d' does not exist.", req.AssignedTo)
+			}
+			p.categories = slices.Clone(p.categories)
+			p.categories[i] = req.CategoryNameNew
+			s.renameCategory(req.ProjectID, req.CategoryName, req.CategoryNameNew)
+			return xsdInteger(1), nil
+		}),
+
+		"mc_project_get_custom_fields": handle(func(s *Server, u *user, req mantis.ProjectGetCustomFieldsRequest) (any, error) {
+			p, err := s.project(req.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			return arrayOf("ns1:CustomFieldDefinitionData", slices.Clone(p.customFields)), nil
+		}),
+
+		"mc_project_get_versions": handle(func(s *Server, u *user, req mantis.ProjectGetVersionsRequest) (any, error) {
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			return arrayOf("ns1:ProjectVersionData", s.projectVersions(req.ProjectID)), nil
+		}),
+
+		"mc_project_get_released_versions": handle(func(s *Server, u *user, req mantis.ProjectGetReleasedVersionsRequest) (any, error) {
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			vv := slices.DeleteFunc(s.projectVersions(req.ProjectID), func(v mantis.ProjectVersionData) bool {
+				return !v.Released || v.Obsolete
+			})
+			return arrayOf("ns1:ProjectVersionData", vv), nil
+		}),
+
+		"mc_project_get_unreleased_versions": handle(func(s *Server, u *user, req mantis.ProjectGetUnreleasedVersionsRequest) (any, error) {
+			if _, err := s.project(req.ProjectID); err != nil {
+				return nil, err
+			}
+			vv := slices.DeleteFunc(s.projectVersions(req.ProjectID), func(v mantis.ProjectVersionData) bool {
+				return v.Released || v.Obsolete
+			})
+			return arrayOf("ns1:ProjectVersionData", vv), nil
+		}),
+
+		"mc_project_version_add": handle(func(s *Server, u *user, req mantis.ProjectVersionAddRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			v := req.Version
+			if _, err := s.project(v.ProjectID); err != nil {
+				return nil, err
+			}
+			if v.Name == "" {
+				return nil, clientFault("Mandatory field 'name' was missing")
+			}
+			for _, o := range s.versions {
+				if o.ProjectID == v.ProjectID && o.Name == v.Name {
+					return nil, clientFault("Version '%s' already exists.", v.Name)
+				}
+			}
+			v.ID = s.nextID("version")
+			if v.DateOrder.IsZero() {
+				t := mantis.Time(s.now())
+				v.DateOrder = &t
+			}
+			s.versions[v.ID] = &v
+			return xsdInteger(v.ID), nil
+		}),
+
+		"mc_project_version_update": handle(func(s *Server, u *user, req mantis.ProjectVersionUpdateRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			old, ok := s.versions[req.VersionID]
+			if !ok {
+				return nil, clientFault("Version '%d' does not exist.", req.VersionID)
+			}
+			v := req.Version
+			v.ID, v.ProjectID = old.ID, old.ProjectID
+			if v.Name == "" {
+				return nil, clientFault("Mandatory field 'name' was missing")
+			}
+			if v.DateOrder.IsZero() {
+				v.DateOrder = old.DateOrder
+			}
+			s.versions[v.ID] = &v
+			return xsdBoolean(true), nil
+		}),
+
+		"mc_project_version_delete": handle(func(s *Server, u *user, req mantis.ProjectVersionDeleteRequest) (any, error) {
+			if u.accessLevel < Manager {
+				return nil, accessDenied(u)
+			}
+			if _, ok := s.versions[req.VersionID]; !ok {
+				return nil, clientFault("Version '%d' does not exist.", req.VersionID)
+			}
+			delete(s.versions, req.VersionID)
+			return xsdBoolean(true), nil
+		}),
+	}
+}
+
+// issueAttachmentAddRequest is mantis.IssueAttachmentAddRequest, with the content as string.
+type issueAttachmentAddRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_attachment_add"`
+	mantis.Auth
+	IssueID  int    `xml:"issue_id"`
+	Name     string `xml:"name"`
+	FileType string `xml:"file_type"`
+	Content  string `xml:"content"`
+}
+
+type tagDataSearchResult struct {
+	Results      soapArray[mantis.TagData] `xml:"results"`
+	TotalResults typed                     `xml:"total_results"`
+}
+
+// projectAttachmentAddRequest is mantis.ProjectAttachmentAddRequest, with the content as string.
+type projectAttachmentAddRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_add"`
+	mantis.Auth
+	ProjectID   int    `xml:"project_id"`
+	Name        string `xml:"name"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	FileType    string `xml:"file_type"`
+	Content     string `xml:"content"`
+}
+
+func (s *Server) issue(issueID int) (mantis.IssueData, error) {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return mantis.IssueData{}, clientFault("Issue '%d' does not exist.", issueID)
+	}
+	return cloneIssue(issue), nil
+}
+
+func (s *Server) project(projectID int) (*project, error) {
+	p, ok := s.projects[projectID]
+	if !ok {
+		return nil, clientFault("Project '%d' does not exist.", projectID)
+	}
+	return p, nil
+}
+
+// projectRef resolves the project reference by ID or name.
+func (s *Server) projectRef(ref *mantis.ObjectRef) (*project, error) {
+	if ref == nil {
+		return nil, clientFault("Project '0' does not exist.")
+	}
+	if ref.ID == 0 && ref.Name != "" {
+		for _, p := range s.projects {
+			if p.Name == ref.Name {
+				return p, nil
+			}
+		}
+		return nil, clientFault("Project '%s' does not exist.", ref.Name)
+	}
+	return s.project(ref.ID)
+}
+
+// setProject sets the fields of p from in, as mc_project_add and mc_project_update do.
+func (s *Server) setProject(p *project, in mantis.ProjectData) error {
+	if strings.TrimSpace(in.Name) == "" {
+		return clientFault("Mandatory field 'name' is missing.")
+	}
+	for _, o := range s.projects {
+		if o != p && strings.EqualFold(o.Name, in.Name) {
+			return clientFault("Project name exists")
+		}
+	}
+	d := in
+	d.ID, d.Subprojects = p.ID, nil
+	if d.InheritGlobal == nil {
+		if d.InheritGlobal = p.InheritGlobal; d.InheritGlobal == nil {
+			inherit := true
+			d.InheritGlobal = &inherit
+		}
+	}
+	var err error
+	if d.Status, err = s.enumRef("project_status", in.Status, 10); err != nil {
+		return err
+	}
+	if d.ViewState, err = s.enumRef("project_view_state", in.ViewState, 10); err != nil {
+		return err
+	}
+	if d.AccessMin, err = s.enumRef("access_levels", in.AccessMin, Viewer); err != nil {
+		return err
+	}
+	p.ProjectData = d
+	return nil
+}
+
+// subprojects returns the projects under parentID, recursively.
+func (s *Server) subprojects(parentID int) []mantis.ProjectData {
+	var pp []mantis.ProjectData
+	for _, p := range s.projects {
+		if p.parentID == parentID {
+			d := p.ProjectData
+			d.Subprojects = s.subprojects(p.ID)
+			pp = append(pp, d)
+		}
+	}
+	slices.SortFunc(pp, func(a, b mantis.ProjectData) int { return cmp.Compare(a.ID, b.ID) })
+	return pp
+}
+
+// projectIDs returns the project and all its subprojects' IDs.
+func (s *Server) projectIDs(projectID int) []int {
+	ids := []int{projectID}
+	for _, p := range s.projects {
+		if p.parentID == projectID {
+			ids = append(ids, s.projectIDs(p.ID)...)
+		}
+	}
+	return ids
+}
+
+func (s *Server) projectVersions(projectID int) []mantis.ProjectVersionData {
+	var vv []mantis.ProjectVersionData
+	for _, v := range s.versions {
+		if v.ProjectID == projectID {
+			vv = append(vv, *v)
+		}
+	}
+	// Mantis returns the versions in reverse date order.
+	slices.SortFunc(vv, func(a, b mantis.ProjectVersionData) int {
+		return cmp.Or(
+			time.Time(*b.DateOrder).Compare(time.Time(*a.DateOrder)),
+			cmp.Compare(b.ID, a.ID))
+	})
+	return vv
+}
+
+// renameCategory renames the category of the project's issues - Mantis references them by ID.
+func (s *Server) renameCategory(projectID int, name, newName string) {
+	for _, issue := range s.issues {
+		if issue.Project.ID == projectID && issue.Category != nil && *issue.Category == name {
+			issue.Category = &newName
+		}
+	}
+}
+
+// projectCategories returns the project's own categories,
+// followed by the inherited ones of its parents and the global ones.
+func (s *Server) projectCategories(p *project) []string {
+	categories := slices.Clone(p.categories)
+	var inherited []string
+	if parent := s.projects[p.parentID]; parent != nil {
+		inherited = s.projectCategories(parent)
+	}
+	if p.InheritGlobal == nil || *p.InheritGlobal {
+		inherited = append(inherited, s.categories...)
+	}
+	for _, name := range inherited {
+		if !slices.Contains(categories, name) {
+			categories = append(categories, name)
+		}
+	}
+	return categories
+}
+
+func (s *Server) hasCategory(p *project, category string) bool {
+	return p != nil && slices.Contains(s.projectCategories(p), category)
+}
+
+// enumRef resolves the reference by ID or name in the named enumeration,
+// returning the default if ref is nil.
+func (s *Server) enumRef(enum string, ref *mantis.ObjectRef, def int) (*mantis.ObjectRef, error) {
+	if ref == nil || (ref.ID == 0 && ref.Name == "") {
+		ref = &mantis.ObjectRef{ID: def}
+	}
+	for _, e := range s.enums[enum] {
+		if (ref.ID != 0 && e.ID == ref.ID) || (ref.ID == 0 && e.Name == ref.Name) {
+			return &e, nil
+		}
+	}
+	if ref.ID != 0 {
+		return nil, clientFault("Invalid %s '%d'.", enum, ref.ID)
+	}
+	return nil, clientFault("Invalid %s '%s'.", enum, ref.Name)
+}
+
+// accountRef resolves the account by ID or name.
+func (s *Server) accountRef(a *mantis.AccountData) (*mantis.AccountData, error) {
+	if a == nil || (a.ID == 0 && a.Name == "") {
+		return nil, nil
+	}
+	for _, u := range s.users {
+		if (a.ID != 0 && u.ID == a.ID) || (a.ID == 0 && u.Name == a.Name) {
+			acc := u.AccountData
+			return &acc, nil
+		}
+	}
+	if a.ID != 0 {
+		return nil, clientFault("User '%d' does not exist.", a.ID)
+	}
+	return nil, clientFault("User '%s' does not exist.", a.Name)
+}
+
+func (s *Server) addIssue(u *user, in mantis.IssueData) (int, error) {
+	p, err := s.projectRef(in.Project)
+	if err != nil {
+		return 0, err
+	}
+	if in.Summary == nil || strings.TrimSpace(*in.Summary) == "" {
+		return 0, clientFault("Mandatory field 'summary' is missing.")
+	}
+	if in.Description == nil || strings.TrimSpace(*in.Description) == "" {
+		return 0, clientFault("Mandatory field 'description' is missing.")
+	}
+	if in.Category != nil && *in.Category != "" && !s.hasCategory(p, *in.Category) {
+		return 0, clientFault("Category '%s' not found for project '%d'.", *in.Category, p.ID)
+	}
+	now := mantis.Time(s.now())
+	issue := in
+	iID := mantis.IssueID(s.nextID("issue"))
+	issue.ID = &iID
+	issue.Project = &mantis.ObjectRef{ID: p.ID, Name: p.Name}
+	issue.DateSubmitted, issue.LastUpdated = &now, &now
+	issue.Attachments, issue.Relationships, issue.Notes = nil, nil, nil
+	if issue.Reporter, err = s.accountRef(in.Reporter); err != nil {
+		return 0, err
+	} else if issue.Reporter == nil {
+		acc := u.AccountData
+		issue.Reporter = &acc
+	}
+	if issue.Handler, err = s.accountRef(in.Handler); err != nil {
+		return 0, err
+	}
+	for _, x := range []struct {
+		dst  **mantis.ObjectRef
+		enum string
+		def  int
+	}{
+		{&issue.Status, "status", 10},
+		{&issue.Priority, "priority", 30},
+		{&issue.Severity, "severity", 50},
+		{&issue.Reproducibility, "reproducibility", 70},
+		{&issue.Resolution, "resolution", 10},
+		{&issue.Projection, "projection", 10},
+		{&issue.ETA, "eta", 10},
+		{&issue.ViewState, "view_state", 10},
+	} {
+		if *x.dst, err = s.enumRef(x.enum, *x.dst, x.def); err != nil {
+			return 0, err
+		}
+	}
+	if issue.Monitors, err = s.accounts(in.Monitors); err != nil {
+		return 0, err
+	}
+	s.issues[int(iID)] = &issue
+	s.addHistory(u, int(iID), mantis.HistoryIssueCreated, "", "", "")
+	for _, n := range in.Notes {
+		if _, err := s.addNote(u, int(iID), mantis.IssueNoteData{
+			Text: n.Text, ViewState: n.ViewState, TimeTracking: &n.TimeTracking,
+		}); err != nil {
+			delete(s.issues, int(iID))
+			return 0, err
+		}
+	}
+	return int(iID), nil
+}
+
+func (s *Server) accounts(aa []mantis.AccountData) ([]mantis.AccountData, error) {
+	if len(aa) == 0 {
+		return nil, nil
+	}
+	result := make([]mantis.AccountData, 0, len(aa))
+	for _, a := range aa {
+		acc, err := s.accountRef(&a)
+		if err != nil {
+			return nil, err
+		}
+		if acc != nil && !slices.ContainsFunc(result, func(b mantis.AccountData) bool { return b.ID == acc.ID }) {
+			result = append(result, *acc)
+		}
+	}
+	return result, nil
+}
+
+// updateIssue updates the issue with the non-nil fields of in.
+func (s *Server) updateIssue(u *user, issueID int, in mantis.IssueData) error {
+	old, ok := s.issues[issueID]
+	if !ok {
+		return clientFault("Issue '%d' does not exist.", issueID)
+	}
+	issue := cloneIssue(old)
+	var err error
+	if in.Project != nil {
+		p, err := s.projectRef(in.Project)
+		if err != nil {
+			return err
+		}
+		issue.Project = &mantis.ObjectRef{ID: p.ID, Name: p.Name}
+	}
+	if in.Summary != nil && strings.TrimSpace(*in.Summary) == "" {
+		return clientFault("Mandatory field 'summary' is missing.")
+	}
+	if in.Category != nil && *in.Category != "" && !s.hasCategory(s.projects[issue.Project.ID], *in.Category) {
+		return clientFault("Category '%s' not found for project '%d'.", *in.Category, issue.Project.ID)
+	}
+	for _, x := range []struct{ dst, src **string }{
+		{&issue.Category, &in.Category},
+		{&issue.Summary, &in.Summary},
+		{&issue.Version, &in.Version},
+		{&issue.Build, &in.Build},
+		{&issue.Platform, &in.Platform},
+		{&issue.Os, &in.Os},
+		{&issue.OsBuild, &in.OsBuild},
+		{&issue.FixedInVersion, &in.FixedInVersion},
+		{&issue.TargetVersion, &in.TargetVersion},
+		{&issue.Description, &in.Description},
+		{&issue.StepsToReproduce, &in.StepsToReproduce},
+		{&issue.AdditionalInformation, &in.AdditionalInformation},
+	} {
+		if *x.src != nil {
+			v := **x.src
+			*x.dst = &v
+		}
+	}
+	for _, x := range []struct {
+		dst  **mantis.ObjectRef
+		src  *mantis.ObjectRef
+		enum string
+	}{
+		{&issue.Status, in.Status, "status"},
+		{&issue.Priority, in.Priority, "priority"},
+		{&issue.Severity, in.Severity, "severity"},
+		{&issue.Reproducibility, in.Reproducibility, "reproducibility"},
+		{&issue.Resolution, in.Resolution, "resolution"},
+		{&issue.Projection, in.Projection, "projection"},
+		{&issue.ETA, in.ETA, "eta"},
+		{&issue.ViewState, in.ViewState, "view_state"},
+	} {
+		if x.src != nil {
+			if *x.dst, err = s.enumRef(x.enum, x.src, 0); err != nil {
+				return err
+			}
+		}
+	}
+	if in.Handler != nil {
+		if issue.Handler, err = s.accountRef(in.Handler); err != nil {
+			return err
+		}
+	}
+	if in.Monitors != nil {
+		if issue.Monitors, err = s.accounts(in.Monitors); err != nil {
+			return err
+		}
+	}
+	if in.DueDate != nil {
+		t := *in.DueDate
+		issue.DueDate = &t
+	}
+	if in.Sticky != nil {
+		b := *in.Sticky
+		issue.Sticky = &b
+	}
+	for _, f := range in.CustomFields {
+		i := slices.IndexFunc(issue.CustomFields, func(g mantis.CustomFieldData) bool {
+			return (f.Field.ID != 0 && g.Field.ID == f.Field.ID) || (f.Field.ID == 0 && g.Field.Name == f.Field.Name)
+		})
+		if i < 0 {
+			issue.CustomFields = append(issue.CustomFields, f)
+		} else {
+			issue.CustomFields[i].Value = f.Value
+		}
+	}
+	now := mantis.Time(s.now())
+	issue.LastUpdated = &now
+	s.addChangeHistory(u, old, &issue)
+	*old = issue
+	// Notes without ID are added, as Mantis does.
+	for _, n := range in.Notes {
+		if n.ID != 0 {
+			continue
+		}
+		tt := n.TimeTracking
+		if _, err := s.addNote(u, issueID, mantis.IssueNoteData{
+			Text: n.Text, ViewState: n.ViewState, TimeTracking: &tt,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) addNote(u *user, issueID int, in mantis.IssueNoteData) (int, error) {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return 0, clientFault("Issue '%d' does not exist.", issueID)
+	}
+	if strings.TrimSpace(in.Text) == "" {
+		return 0, clientFault("Issue note text must not be blank.")
+	}
+	viewState, err := s.enumRef("view_state", in.ViewState, 10)
+	if err != nil {
+		return 0, err
+	}
+	now := mantis.Time(s.now())
+	note := mantis.NoteData{
+		ID: s.nextID("note"), Reporter: u.AccountData,
+		Text: in.Text, ViewState: viewState,
+		DateSubmitted: now, LastModified: now,
+		NoteAttr: in.NoteAttr,
+	}
+	if in.TimeTracking != nil {
+		note.TimeTracking = *in.TimeTracking
+	}
+	if in.NoteType != nil {
+		note.NoteType = *in.NoteType
+	}
+	issue.Notes = append(slices.Clip(issue.Notes), note)
+	issue.LastUpdated = &now
+	s.addHistory(u, issueID, mantis.HistoryNoteAdded, "", fmt.Sprintf("%07d", note.ID), "")
+	return note.ID, nil
+}
+
+// ownNote returns the note, if the user may modify it:
+// the reporters their own notes, the developers anyone's.
+func (s *Server) ownNote(u *user, noteID int) (*mantis.IssueData, *mantis.NoteData, error) {
+	for _, issue := range s.issues {
+		for i := range issue.Notes {
+			note := &issue.Notes[i]
+			if note.ID != noteID {
+				continue
+			}
+			if note.Reporter.ID != u.ID && u.accessLevel < Developer {
+				return nil, nil, accessDenied(u)
+			}
+			return issue, note, nil
+		}
+	}
+	return nil, nil, clientFault("Issue note '%d' does not exist.", noteID)
+}
+
+func (s *Server) addAttachment(u *user, issueID int, name, contentType string, content []byte) (int, error) {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return 0, clientFault("Issue '%d' does not exist.", issueID)
+	}
+	if name == "" {
+		return 0, clientFault("Mandatory field 'name' is missing.")
+	}
+	id := s.nextID("attachment")
+	a := attachment{
+		AttachmentData: mantis.AttachmentData{
+			ID: id, FileName: name, Size: len(content), ContentType: contentType,
+			DateSubmitted: mantis.Time(s.now()),
+			DownloadURL:   fmt.Sprintf("%s/file_download.php?file_id=%d&type=bug", s.URL, id),
+			UserID:        u.ID,
+		},
+		issueID: issueID, content: content,
+	}
+	s.attachments[id] = &a
+	issue.Attachments = append(slices.Clip(issue.Attachments), a.AttachmentData)
+	now := mantis.Time(s.now())
+	issue.LastUpdated = &now
+	s.addHistory(u, issueID, mantis.HistoryFileAdded, "", name, "")
+	return id, nil
+}
+
+// addRelationship adds the relationship to both issues, reversed for the target,
+// just as Mantis shows it.
+func (s *Server) addRelationship(u *user, issueID int, typ mantis.RelationshipType, targetID int) (int, error) {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return 0, clientFault("Issue '%d' does not exist.", issueID)
+	}
+	target, ok := s.issues[targetID]
+	if !ok {
+		return 0, clientFault("Issue '%d' does not exist.", targetID)
+	}
+	if issueID == targetID {
+		return 0, clientFault("An issue can't be related to itself.")
+	}
+	if typ < mantis.DuplicateOf || typ > mantis.HasDuplicate {
+		return 0, clientFault("Invalid relationship type '%d'.", typ)
+	}
+	if slices.ContainsFunc(issue.Relationships, func(r mantis.RelationshipData) bool { return r.TargetID == targetID }) {
+		return 0, clientFault("Relationship already exists.")
+	}
+	id := s.nextID("relationship")
+	issue.Relationships = append(slices.Clip(issue.Relationships), mantis.RelationshipData{
+		ID: id, Type: mantis.ObjectRef{ID: int(typ), Name: typ.String()}, TargetID: targetID,
+	})
+	rev := typ.Reverse()
+	target.Relationships = append(slices.Clip(target.Relationships), mantis.RelationshipData{
+		ID: id, Type: mantis.ObjectRef{ID: int(rev), Name: rev.String()}, TargetID: issueID,
+	})
+	now := mantis.Time(s.now())
+	issue.LastUpdated, target.LastUpdated = &now, &now
+	s.addHistory(u, issueID, mantis.HistoryRelationshipAdded, "", strconv.Itoa(int(typ)), strconv.Itoa(targetID))
+	s.addHistory(u, targetID, mantis.HistoryRelationshipAdded, "", strconv.Itoa(int(rev)), strconv.Itoa(issueID))
+	return id, nil
+}
+
+func (s *Server) deleteRelationship(u *user, issueID, relationshipID int) error {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return clientFault("Issue '%d' does not exist.", issueID)
+	}
+	i := slices.IndexFunc(issue.Relationships, func(r mantis.RelationshipData) bool { return r.ID == relationshipID })
+	if i < 0 {
+		return clientFault("Relationship '%d' does not exist.", relationshipID)
+	}
+	now := mantis.Time(s.now())
+	rel := issue.Relationships[i]
+	if target, ok := s.issues[rel.TargetID]; ok {
+		target.Relationships = slices.DeleteFunc(slices.Clone(target.Relationships),
+			func(r mantis.RelationshipData) bool { return r.ID == relationshipID })
+		target.LastUpdated = &now
+		rev := rel.RelationshipType().Reverse()
+		s.addHistory(u, rel.TargetID, mantis.HistoryRelationshipDeleted, "", strconv.Itoa(int(rev)), strconv.Itoa(issueID))
+	}
+	s.addHistory(u, issueID, mantis.HistoryRelationshipDeleted, "", strconv.Itoa(rel.Type.ID), strconv.Itoa(rel.TargetID))
+	issue.Relationships = slices.Delete(slices.Clone(issue.Relationships), i, i+1)
+	issue.LastUpdated = &now
+	return nil
+}
+
+func (s *Server) addTag(u *user, name, description string) (int, error) {
+	if strings.TrimSpace(name) == "" {
+		return 0, clientFault("Mandatory field 'name' was missing")
+	}
+	for _, t := range s.tags {
+		if strings.EqualFold(t.Name, name) {
+			return 0, clientFault("A tag with the same name already exists.")
+		}
+	}
+	now := mantis.Time(s.now())
+	acc := u.AccountData
+	t := mantis.TagData{ID: s.nextID("tag"), User: &acc, Name: name, Description: description,
+		DateCreated: &now, DateUpdated: &now}
+	s.tags[t.ID] = &t
+	return t.ID, nil
+}
+
+// setTags replaces the tags of the issue, given by their ID.
+func (s *Server) setTags(u *user, issueID int, in []mantis.TagData) error {
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return clientFault("Issue '%d' does not exist.", issueID)
+	}
+	tags := make([]mantis.ObjectRef, 0, len(in))
+	for _, t := range in {
+		tag, ok := s.tags[t.ID]
+		if !ok {
+			return clientFault("Tag '%d' does not exist.", t.ID)
+		}
+		if !slices.ContainsFunc(tags, func(r mantis.ObjectRef) bool { return r.ID == t.ID }) {
+			tags = append(tags, mantis.ObjectRef{ID: tag.ID, Name: tag.Name})
+		}
+	}
+	for _, t := range issue.Tags {
+		if !slices.ContainsFunc(tags, func(r mantis.ObjectRef) bool { return r.ID == t.ID }) {
+			s.addHistory(u, issueID, mantis.HistoryTagDetached, "", t.Name, "")
+		}
+	}
+	for _, t := range tags {
+		if !slices.ContainsFunc(issue.Tags, func(r mantis.ObjectRef) bool { return r.ID == t.ID }) {
+			s.addHistory(u, issueID, mantis.HistoryTagAttached, "", t.Name, "")
+		}
+	}
+	issue.Tags = tags
+	now := mantis.Time(s.now())
+	issue.LastUpdated = &now
+	return nil
+}
+
+func (s *Server) addHistory(u *user, issueID int, typ mantis.HistoryType, field, oldValue, newValue string) {
+	s.history[issueID] = append(s.history[issueID], mantis.HistoryData{
+		Date: s.now().Unix(), UserID: u.ID, Username: u.Name,
+		Field: field, Type: typ, OldValue: oldValue, NewValue: newValue,
+	})
+}
+
+// addChangeHistory records the changed fields, with the raw values, as Mantis does.
+func (s *Server) addChangeHistory(u *user, old, issue *mantis.IssueData) {
+	str := func(p *string) string {
+		if p == nil {
+			return ""
+		}
+		return *p
+	}
+	ref := func(r *mantis.ObjectRef) string {
+		if r == nil {
+			return "0"
+		}
+		return strconv.Itoa(r.ID)
+	}
+	account := func(a *mantis.AccountData) string {
+		if a == nil {
+			return "0"
+		}
+		return strconv.Itoa(a.ID)
+	}
+	for _, f := range []struct{ field, old, new string }{
+		{"project_id", ref(old.Project), ref(issue.Project)},
+		{"category", str(old.Category), str(issue.Category)},
+		{"summary", str(old.Summary), str(issue.Summary)},
+		{"status", ref(old.Status), ref(issue.Status)},
+		{"handler_id", account(old.Handler), account(issue.Handler)},
+		{"priority", ref(old.Priority), ref(issue.Priority)},
+		{"severity", ref(old.Severity), ref(issue.Severity)},
+		{"reproducibility", ref(old.Reproducibility), ref(issue.Reproducibility)},
+		{"resolution", ref(old.Resolution), ref(issue.Resolution)},
+		{"projection", ref(old.Projection), ref(issue.Projection)},
+		{"eta", ref(old.ETA), ref(issue.ETA)},
+		{"view_state", ref(old.ViewState), ref(issue.ViewState)},
+		{"version", str(old.Version), str(issue.Version)},
+		{"fixed_in_version", str(old.FixedInVersion), str(issue.FixedInVersion)},
+		{"target_version", str(old.TargetVersion), str(issue.TargetVersion)},
+		{"platform", str(old.Platform), str(issue.Platform)},
+		{"os", str(old.Os), str(issue.Os)},
+		{"os_build", str(old.OsBuild), str(issue.OsBuild)},
+	} {
+		if f.old != f.new {
+			s.addHistory(u, int(*issue.ID), mantis.HistoryFieldChanged, f.field, f.old, f.new)
+		}
+	}
+	for _, f := range []struct {
+		typ      mantis.HistoryType
+		old, new *string
+	}{
+		{mantis.HistoryDescriptionUpdated, old.Description, issue.Description},
+		{mantis.HistoryStepsToReproduceUpdated, old.StepsToReproduce, issue.StepsToReproduce},
+		{mantis.HistoryAdditionalInfoUpdated, old.AdditionalInformation, issue.AdditionalInformation},
+	} {
+		if str(f.old) != str(f.new) {
+			s.addHistory(u, int(*issue.ID), f.typ, "", "", "")
+		}
+	}
+}
+
+// hideStatusDefault is Mantis' hide_status_default: closed.
+const hideStatusDefault = 90
+
+// searchFilter returns the filter of the mc_filter_search_* calls with Mantis' defaults:
+// without hide_status, the closed issues are hidden.
+func searchFilter(filter mantis.FilterSearchData) mantis.FilterSearchData {
+	if len(filter.HideStatusID) == 0 {
+		filter.HideStatusID = []int{hideStatusDefault}
+	}
+	return filter
+}
+
+// search returns the issues matching the filter, in the default
+// (last updated, descending) or the requested order.
+func (s *Server) search(filter mantis.FilterSearchData) []*mantis.IssueData {
+	var projectIDs []int
+	for _, id := range filter.ProjectID {
+		projectIDs = append(projectIDs, s.projectIDs(id)...)
+	}
+	var issues []*mantis.IssueData
+	for _, issue := range s.issues {
+		if matches(issue, filter, projectIDs) {
+			issues = append(issues, issue)
+		}
+	}
+	sortKey := func(issue *mantis.IssueData) int64 {
+		switch filter.Sort {
+		case "id":
+			return int64(*issue.ID)
+		case "date_submitted":
+			return time.Time(*issue.DateSubmitted).UnixNano()
+		case "priority":
+			return int64(issue.Priority.ID)
+		case "severity":
+			return int64(issue.Severity.ID)
+		case "status":
+			return int64(issue.Status.ID)
+		default:
+			return time.Time(*issue.LastUpdated).UnixNano()
+		}
+	}
+	asc := filter.SortDirection != nil && strings.EqualFold(*filter.SortDirection, "ASC")
+	slices.SortFunc(issues, func(a, b *mantis.IssueData) int {
+		c := cmp.Or(cmp.Compare(sortKey(a), sortKey(b)), cmp.Compare(*a.ID, *b.ID))
+		if asc {
+			return c
+		}
+		return -c
+	})
+	return issues
+}
+
+// filterDate returns the start of the day, if all parts are given.
+func filterDate(year, month, day *int) (time.Time, bool) {
+	if year == nil || month == nil || day == nil {
+		return time.Time{}, false
+	}
+	return time.Date(*year, time.Month(*month), *day, 0, 0, 0, 0, time.UTC), true
+}
+
+func matches(issue *mantis.IssueData, filter mantis.FilterSearchData, projectIDs []int) bool {
+	in := func(ids []int, ref *mantis.ObjectRef) bool {
+		return len(ids) == 0 || (ref != nil && slices.Contains(ids, ref.ID))
+	}
+	inAccount := func(ids []int, a *mantis.AccountData) bool {
+		if len(ids) == 0 {
+			return true
+		}
+		if a == nil {
+			// 0 means "none".
+			return slices.Contains(ids, 0)
+		}
+		return slices.Contains(ids, a.ID)
+	}
+	inStrings := func(ss []string, s *string) bool {
+		if len(ss) == 0 {
+			return true
+		}
+		var v string
+		if s != nil {
+			v = *s
+		}
+		return slices.Contains(ss, v)
+	}
+	if len(projectIDs) != 0 && !slices.Contains(projectIDs, issue.Project.ID) {
+		return false
+	}
+	if !(in(filter.SeverityID, issue.Severity) && in(filter.StatusID, issue.Status) &&
+		in(filter.PriorityID, issue.Priority) && in(filter.ResolutionID, issue.Resolution) &&
+		in(filter.ViewStateID, issue.ViewState) &&
+		inAccount(filter.ReporterID, issue.Reporter) && inAccount(filter.HandlerID, issue.Handler) &&
+		inStrings(filter.Category, issue.Category) &&
+		inStrings(filter.ProductVersion, issue.Version) &&
+		inStrings(filter.FixedInVersion, issue.FixedInVersion) &&
+		inStrings(filter.TargetVersion, issue.TargetVersion) &&
+		inStrings(filter.Platform, issue.Platform) &&
+		inStrings(filter.OS, issue.Os) && inStrings(filter.OSBuild, issue.OsBuild)) {
+		return false
+	}
+	for _, h := range filter.HideStatusID {
+		if h > 0 && issue.Status.ID >= h {
+			return false
+		}
+	}
+	if filter.Sticky != nil && (issue.Sticky != nil && *issue.Sticky) != *filter.Sticky {
+		return false
+	}
+	if issue.LastUpdated != nil {
+		lastUpdated := time.Time(*issue.LastUpdated)
+		if start, ok := filterDate(filter.LastUpdateStartYear, filter.LastUpdateStartMonth, filter.LastUpdateStartDay); ok &&
+			lastUpdated.Before(start) {
+			return false
+		}
+		if end, ok := filterDate(filter.LastUpdateEndYear, filter.LastUpdateEndMonth, filter.LastUpdateEndDay); ok &&
+			!lastUpdated.Before(end.AddDate(0, 0, 1)) {
+			return false
+		}
+	}
+	if len(filter.NoteUserID) != 0 && !slices.ContainsFunc(issue.Notes, func(n mantis.NoteData) bool {
+		return slices.Contains(filter.NoteUserID, n.Reporter.ID)
+	}) {
+		return false
+	}
+	if len(filter.UserMonitorID) != 0 && !slices.ContainsFunc(issue.Monitors, func(a mantis.AccountData) bool {
+		return slices.Contains(filter.UserMonitorID, a.ID)
+	}) {
+		return false
+	}
+	for _, tag := range filter.TagString {
+		if !slices.ContainsFunc(issue.Tags, func(t mantis.ObjectRef) bool { return t.Name == tag }) {
+			return false
+		}
+	}
+	for _, f := range filter.CustomFields {
+		i := slices.IndexFunc(issue.CustomFields, func(g mantis.CustomFieldData) bool {
+			return (f.Field.ID != 0 && g.Field.ID == f.Field.ID) || (f.Field.ID == 0 && g.Field.Name == f.Field.Name)
+		})
+		if i < 0 || !slices.Contains(f.Value, issue.CustomFields[i].Value) {
+			return false
+		}
+	}
+	if !inDateRange(time.Time(*issue.DateSubmitted),
+		filter.StartYear, filter.StartMonth, filter.StartDay,
+		filter.EndYear, filter.EndMonth, filter.EndDay) ||
+		!inDateRange(time.Time(*issue.LastUpdated),
+			filter.LastUpdateStartYear, filter.LastUpdateStartMonth, filter.LastUpdateStartDay,
+			filter.LastUpdateEndYear, filter.LastUpdateEndMonth, filter.LastUpdateEndDay) {
+		return false
+	}
+	if q := strings.TrimSpace(filter.Search); q != "" {
+		if id, err := strconv.Atoi(strings.TrimPrefix(q, "#")); err == nil && id == int(*issue.ID) {
+			return true
+		}
+		q = strings.ToLower(q)
+		for _, s := range []*string{issue.Summary, issue.Description, issue.StepsToReproduce, issue.AdditionalInformation} {
+			if s != nil && strings.Contains(strings.ToLower(*s), q) {
+				return true
+			}
+		}
+		return slices.ContainsFunc(issue.Notes, func(n mantis.NoteData) bool {
+			return strings.Contains(strings.ToLower(n.Text), q)
+		})
+	}
+	return true
+}
+
+func inDateRange(t time.Time, startYear, startMonth, startDay, endYear, endMonth, endDay *int) bool {
+	date := func(y, m, d *int) (time.Time, bool) {
+		if y == nil || m == nil || d == nil {
+			return time.Time{}, false
+		}
+		return time.Date(*y, time.Month(*m), *d, 0, 0, 0, 0, t.Location()), true
+	}
+	if start, ok := date(startYear, startMonth, startDay); ok && t.Before(start) {
+		return false
+	}
+	if end, ok := date(endYear, endMonth, endDay); ok && !t.Before(end.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
+
+// paginate returns the pageNumber-th page (1-based) of perPage items.
+//
+// Just as Mantis, it returns the last page for page numbers after that,
+// and all items for a non-positive perPage.
+// issueHeaders returns the headers of the issues.
+func issueHeaders(issues []*mantis.IssueData) []mantis.IssueHeaderData {
+	refID := func(ref *mantis.ObjectRef) int {
+		if ref == nil {
+			return 0
+		}
+		return ref.ID
+	}
+	accountID := func(acc *mantis.AccountData) int {
+		if acc == nil {
+			return 0
+		}
+		return acc.ID
+	}
+	headers := make([]mantis.IssueHeaderData, len(issues))
+	for i, issue := range issues {
+		h := mantis.IssueHeaderData{
+			ID: *issue.ID, LastUpdated: issue.LastUpdated,
+			ViewState: refID(issue.ViewState), Project: refID(issue.Project),
+			Priority: refID(issue.Priority), Severity: refID(issue.Severity),
+			Status: refID(issue.Status), Resolution: refID(issue.Resolution),
+			Reporter: accountID(issue.Reporter), Handler: accountID(issue.Handler),
+			AttachmentsCount: len(issue.Attachments), NotesCount: len(issue.Notes),
+		}
+		if issue.Category != nil {
+			h.Category = *issue.Category
+		}
+		if issue.Summary != nil {
+			h.Summary = *issue.Summary
+		}
+		headers[i] = h
+	}
+	return headers
+}
+
+func paginate[T any](items []T, pageNumber, perPage int) []T {
+	if perPage <= 0 || len(items) == 0 {
+		return items
+	}
+	pageCount := (len(items) + perPage - 1) / perPage
+	pageNumber = min(max(pageNumber, 1), pageCount)
+	return items[(pageNumber-1)*perPage : min(pageNumber*perPage, len(items))]
+}
+
+// enumHandler returns the handler of an mc_enum_* operation, returning the enum.
+func enumHandler[Req any](enum string) handlerFunc {
+	return handle(func(s *Server, u *user, req Req) (any, error) {
+		return arrayOf("ns1:ObjectRef", s.enums[enum]), nil
+	})
+}
+
+// vim: set fileencoding=utf-8 noet:
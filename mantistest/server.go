@@ -0,0 +1,647 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mantistest provides an in-process fake MantisConnect server,
+// for testing code using the mantis package without a live Mantis.
+//
+// The server answers the SOAP endpoint (/api/soap/mantisconnect.php) and
+// the /users/me part of the REST API (/api/rest/index.php) from an in-memory store.
+package mantistest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+const (
+	// DefaultUser is the name of the administrator, created by NewServer.
+	DefaultUser = "administrator"
+	// DefaultPassword is the password of DefaultUser.
+	DefaultPassword = "root"
+
+	SOAPPath = "/api/soap/mantisconnect.php"
+	RESTPath = "/api/rest/index.php"
+)
+
+// Access levels, as in Mantis' config_defaults_inc.php.
+const (
+	Viewer    = 10
+	Reporter  = 25
+	Updater   = 40
+	Developer = 55
+	Manager   = 70
+	Admin     = 90
+)
+
+// Server is a fake MantisConnect server.
+type Server struct {
+	*httptest.Server
+
+	// Now returns the current time, used for the timestamps - time.Now if nil.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	seq         map[string]int
+	users       map[int]*user
+	projects    map[int]*project
+	categories  []string // the global ones
+	issues      map[int]*mantis.IssueData
+	versions    map[int]*mantis.ProjectVersionData
+	attachments map[int]*attachment
+	history     map[int][]mantis.HistoryData
+	tags        map[int]*mantis.TagData
+	filters     map[int]*mantis.FilterSearchData
+	disabled    map[string]bool
+	enums       map[string][]mantis.ObjectRef
+}
+
+type user struct {
+	mantis.AccountData
+	password    string
+	accessLevel int
+	tokens      map[string]apiToken
+}
+
+type apiToken struct {
+	ID    int
+	Token string
+}
+
+type project struct {
+	mantis.ProjectData
+	parentID     int
+	categories   []string
+	customFields []mantis.CustomFieldDefinitionData
+}
+
+type attachment struct {
+	mantis.AttachmentData
+	issueID   int
+	projectID int
+	content   []byte
+}
+
+// NewServer starts and returns a new Server, with the DefaultUser already created.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		seq:         make(map[string]int),
+		users:       make(map[int]*user),
+		projects:    make(map[int]*project),
+		issues:      make(map[int]*mantis.IssueData),
+		versions:    make(map[int]*mantis.ProjectVersionData),
+		attachments: make(map[int]*attachment),
+		history:     make(map[int][]mantis.HistoryData),
+		tags:        make(map[int]*mantis.TagData),
+		filters:     make(map[int]*mantis.FilterSearchData),
+		disabled:    make(map[string]bool),
+		enums: map[string][]mantis.ObjectRef{
+			"status": {{ID: 10, Name: "new"}, {ID: 20, Name: "feedback"},
+				{ID: 30, Name: "acknowledged"}, {ID: 40, Name: "confirmed"},
+				{ID: 50, Name: "assigned"}, {ID: 80, Name: "resolved"},
+				{ID: 90, Name: "closed"}},
+			"priority": {{ID: 10, Name: "none"}, {ID: 20, Name: "low"},
+				{ID: 30, Name: "normal"}, {ID: 40, Name: "high"},
+				{ID: 50, Name: "urgent"}, {ID: 60, Name: "immediate"}},
+			"severity": {{ID: 10, Name: "feature"}, {ID: 20, Name: "trivial"},
+				{ID: 30, Name: "text"}, {ID: 40, Name: "tweak"},
+				{ID: 50, Name: "minor"}, {ID: 60, Name: "major"},
+				{ID: 70, Name: "crash"}, {ID: 80, Name: "block"}},
+			"reproducibility": {{ID: 10, Name: "always"}, {ID: 30, Name: "sometimes"},
+				{ID: 50, Name: "random"}, {ID: 70, Name: "have not tried"},
+				{ID: 90, Name: "unable to reproduce"}, {ID: 100, Name: "N/A"}},
+			"resolution": {{ID: 10, Name: "open"}, {ID: 20, Name: "fixed"},
+				{ID: 30, Name: "reopened"}, {ID: 40, Name: "unable to reproduce"},
+				{ID: 50, Name: "not fixable"}, {ID: 60, Name: "duplicate"},
+				{ID: 70, Name: "no change required"}, {ID: 80, Name: "suspended"},
+				{ID: 90, Name: "won't fix"}},
+			"projection": {{ID: 10, Name: "none"}, {ID: 30, Name: "tweak"},
+				{ID: 50, Name: "minor fix"}, {ID: 70, Name: "major rework"},
+				{ID: 90, Name: "redesign"}},
+			"eta": {{ID: 10, Name: "none"}, {ID: 20, Name: "< 1 day"},
+				{ID: 30, Name: "2-3 days"}, {ID: 40, Name: "< 1 week"},
+				{ID: 50, Name: "< 1 month"}, {ID: 60, Name: "> 1 month"}},
+			"view_state": {{ID: 10, Name: "public"}, {ID: 50, Name: "private"}},
+			"access_levels": {{ID: Viewer, Name: "viewer"}, {ID: Reporter, Name: "reporter"},
+				{ID: Updater, Name: "updater"}, {ID: Developer, Name: "developer"},
+				{ID: Manager, Name: "manager"}, {ID: Admin, Name: "administrator"}},
+			"project_status": {{ID: 10, Name: "development"}, {ID: 30, Name: "release"},
+				{ID: 50, Name: "stable"}, {ID: 70, Name: "obsolete"}},
+			"project_view_state": {{ID: 10, Name: "public"}, {ID: 50, Name: "private"}},
+			"custom_field_type": {{ID: 0, Name: "string"}, {ID: 1, Name: "numeric"},
+				{ID: 2, Name: "float"}, {ID: 3, Name: "enum"}, {ID: 4, Name: "email"},
+				{ID: 5, Name: "checkbox"}, {ID: 6, Name: "list"},
+				{ID: 7, Name: "multiselection list"}, {ID: 8, Name: "date"},
+				{ID: 9, Name: "radio"}, {ID: 10, Name: "textarea"}},
+		},
+	}
+	s.AddUser(mantis.AccountData{Name: DefaultUser, RealName: "Administrator", Email: "root@localhost"}, DefaultPassword, Admin)
+	mux := http.NewServeMux()
+	mux.HandleFunc(SOAPPath, s.serveSOAP)
+	mux.HandleFunc(RESTPath+"/", s.serveREST)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Server) nextID(kind string) int {
+	s.seq[kind]++
+	return s.seq[kind]
+}
+
+// AddUser adds a user with the given password and access level, returning the stored account.
+func (s *Server) AddUser(account mantis.AccountData, password string, accessLevel int) mantis.AccountData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account.ID = s.nextID("user")
+	s.users[account.ID] = &user{AccountData: account, password: password, accessLevel: accessLevel}
+	return account
+}
+
+// APIToken creates a new API token for the named user.
+func (s *Server) APIToken(username, tokenName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.userByName(username)
+	if u == nil {
+		return "", fmt.Errorf("user %q not found", username)
+	}
+	return s.createToken(u, tokenName), nil
+}
+
+func (s *Server) createToken(u *user, name string) string {
+	if u.tokens == nil {
+		u.tokens = make(map[string]apiToken)
+	}
+	// Mantis' tokens are 32 characters long.
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	tok := hex.EncodeToString(b[:])
+	u.tokens[name] = apiToken{ID: s.nextID("token"), Token: tok}
+	return tok
+}
+
+// AddProject adds a project (as a subproject of parentID, if that is not 0), returning its ID.
+func (s *Server) AddProject(p mantis.ProjectData, parentID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.ID = s.nextID("project")
+	if p.Status == nil {
+		p.Status = &mantis.ObjectRef{ID: 10, Name: "development"}
+	}
+	if p.ViewState == nil {
+		p.ViewState = &mantis.ObjectRef{ID: 10, Name: "public"}
+	}
+	if p.AccessMin == nil {
+		p.AccessMin = &mantis.ObjectRef{ID: Viewer, Name: "viewer"}
+	}
+	s.projects[p.ID] = &project{ProjectData: p, parentID: parentID}
+	return p.ID
+}
+
+// AddCategory adds a category to the project,
+// or a global one, inherited by the projects with InheritGlobal, for mantis.AllProjects.
+func (s *Server) AddCategory(projectID int, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if projectID == mantis.AllProjects {
+		if !slices.Contains(s.categories, name) {
+			s.categories = append(s.categories, name)
+		}
+		return nil
+	}
+	p := s.projects[projectID]
+	if p == nil {
+		return fmt.Errorf("project %d not found", projectID)
+	}
+	if !slices.Contains(p.categories, name) {
+		p.categories = append(p.categories, name)
+	}
+	return nil
+}
+
+// AddCustomField links a new custom field to the project, returning its ID.
+func (s *Server) AddCustomField(projectID int, def mantis.CustomFieldDefinitionData) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.projects[projectID]
+	if p == nil {
+		return 0, fmt.Errorf("project %d not found", projectID)
+	}
+	def.Field.ID = s.nextID("custom_field")
+	p.customFields = append(p.customFields, def)
+	return def.Field.ID, nil
+}
+
+// AddFilter stores the filter, as if saved on the issue list page, returning its ID.
+func (s *Server) AddFilter(filter mantis.FilterSearchData) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextID("filter")
+	s.filters[id] = &filter
+	return id
+}
+
+// Disable makes the operations unknown, as on an older Mantis.
+func (s *Server) Disable(ops ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, op := range ops {
+		s.disabled[op] = true
+	}
+}
+
+// AddVersion adds a version to the project given in v.ProjectID, returning its ID.
+func (s *Server) AddVersion(v mantis.ProjectVersionData) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.projects[v.ProjectID]; !ok {
+		return 0, fmt.Errorf("project %d not found", v.ProjectID)
+	}
+	v.ID = s.nextID("version")
+	if v.DateOrder.IsZero() {
+		t := mantis.Time(s.now())
+		v.DateOrder = &t
+	}
+	s.versions[v.ID] = &v
+	return v.ID, nil
+}
+
+// AddIssue adds the issue as the DefaultUser would, returning its ID.
+func (s *Server) AddIssue(issue mantis.IssueData) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addIssue(s.userByName(DefaultUser), issue)
+}
+
+// AddNote adds the note to the issue, returning its ID.
+func (s *Server) AddNote(issueID int, note mantis.IssueNoteData) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u := s.userByName(DefaultUser)
+	if note.Reporter.Name != "" {
+		if r := s.userByName(note.Reporter.Name); r != nil {
+			u = r
+		}
+	}
+	return s.addNote(u, issueID, note)
+}
+
+// AddAttachment adds an attachment to the issue, returning its ID.
+func (s *Server) AddAttachment(issueID int, name, contentType string, content []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addAttachment(s.userByName(DefaultUser), issueID, name, contentType, content)
+}
+
+// AddTag adds a tag, created by the DefaultUser, returning its ID.
+func (s *Server) AddTag(name, description string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addTag(s.userByName(DefaultUser), name, description)
+}
+
+// Issue returns a copy of the stored issue.
+func (s *Server) Issue(issueID int) (mantis.IssueData, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	issue, ok := s.issues[issueID]
+	if !ok {
+		return mantis.IssueData{}, false
+	}
+	return cloneIssue(issue), true
+}
+
+// AttachmentContent returns the content of the attachment.
+func (s *Server) AttachmentContent(attachmentID int) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attachments[attachmentID]
+	if !ok {
+		return nil, false
+	}
+	return bytes.Clone(a.content), true
+}
+
+// Version returns a copy of the stored version.
+func (s *Server) Version(versionID int) (mantis.ProjectVersionData, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.versions[versionID]
+	if !ok {
+		return mantis.ProjectVersionData{}, false
+	}
+	return *v, true
+}
+
+func cloneIssue(issue *mantis.IssueData) mantis.IssueData {
+	c := *issue
+	c.Attachments = slices.Clone(c.Attachments)
+	c.Relationships = slices.Clone(c.Relationships)
+	c.Notes = slices.Clone(c.Notes)
+	c.CustomFields = slices.Clone(c.CustomFields)
+	c.Monitors = slices.Clone(c.Monitors)
+	c.Tags = slices.Clone(c.Tags)
+	return c
+}
+
+func (s *Server) userByName(name string) *user {
+	for _, u := range s.users {
+		if u.Name == name {
+			return u
+		}
+	}
+	return nil
+}
+
+// authenticate the user with password or API token.
+func (s *Server) authenticate(auth mantis.Auth) (*user, error) {
+	u := s.userByName(auth.Username)
+	if u == nil {
+		return nil, errLoginFailed
+	}
+	if u.password == auth.Password {
+		return u, nil
+	}
+	for _, t := range u.tokens {
+		if t.Token == auth.Password {
+			return u, nil
+		}
+	}
+	return nil, errLoginFailed
+}
+
+const (
+	nsSOAPEnv = "http://schemas.xmlsoap.org/soap/envelope/"
+	nsMantis  = "http://futureware.biz/mantisconnect"
+
+	envelopeStart = xml.Header + `<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ns1="http://futureware.biz/mantisconnect" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:SOAP-ENC="http://schemas.xmlsoap.org/soap/encoding/" SOAP-ENV:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><SOAP-ENV:Body>`
+	envelopeEnd   = `</SOAP-ENV:Body></SOAP-ENV:Envelope>`
+)
+
+// Fault is a SOAP fault returned by the server.
+type Fault struct {
+	Code, String string
+}
+
+func (f *Fault) Error() string { return f.Code + ": " + f.String }
+
+func clientFault(format string, args ...any) *Fault {
+	return &Fault{Code: "SOAP-ENV:Client", String: fmt.Sprintf(format, args...)}
+}
+
+var errLoginFailed = clientFault("Access denied")
+
+func accessDenied(u *user) *Fault {
+	return clientFault("Access denied for user %s.", u.Name)
+}
+
+func (s *Server) serveSOAP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	d := xml.NewDecoder(r.Body)
+	st, err := findBody(d)
+	if err != nil {
+		s.writeFault(w, clientFault("Bad Request: %v", err))
+		return
+	}
+	op := st.Name.Local
+	h, ok := handlers[op]
+	s.mu.Lock()
+	ok = ok && !s.disabled[op]
+	s.mu.Unlock()
+	if !ok || st.Name.Space != nsMantis {
+		s.writeFault(w, &Fault{Code: "SOAP-ENV:Server", String: "Procedure '" + op + "' not present"})
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString(envelopeStart)
+	s.mu.Lock()
+	result, err := h(s, d, st)
+	if err == nil {
+		err = xml.NewEncoder(&buf).EncodeElement(
+			struct {
+				Return any `xml:"return"`
+			}{Return: result},
+			xml.StartElement{Name: xml.Name{Local: "ns1:" + op + "Response"}},
+		)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		var f *Fault
+		if !errors.As(err, &f) {
+			f = &Fault{Code: "SOAP-ENV:Server", String: err.Error()}
+		}
+		s.writeFault(w, f)
+		return
+	}
+	buf.WriteString(envelopeEnd)
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) writeFault(w http.ResponseWriter, f *Fault) {
+	var buf bytes.Buffer
+	buf.WriteString(envelopeStart)
+	buf.WriteString("<SOAP-ENV:Fault><faultcode>")
+	_ = xml.EscapeText(&buf, []byte(f.Code))
+	buf.WriteString("</faultcode><faultstring>")
+	_ = xml.EscapeText(&buf, []byte(f.String))
+	buf.WriteString("</faultstring></SOAP-ENV:Fault>")
+	buf.WriteString(envelopeEnd)
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// findBody returns the first element in the SOAP Body.
+func findBody(d *xml.Decoder) (xml.StartElement, error) {
+	var inBody bool
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		st, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if inBody {
+			return st, nil
+		}
+		inBody = st.Name.Space == nsSOAPEnv && st.Name.Local == "Body"
+	}
+}
+
+type handlerFunc func(s *Server, d *xml.Decoder, st xml.StartElement) (any, error)
+
+// handle decodes the request, authenticates the user and calls fn.
+func handle[Req any](fn func(s *Server, u *user, req Req) (any, error)) handlerFunc {
+	return func(s *Server, d *xml.Decoder, st xml.StartElement) (any, error) {
+		var req Req
+		if err := d.DecodeElement(&req, &st); err != nil {
+			return nil, clientFault("Bad Request: %v", err)
+		}
+		auth, _ := reflect.ValueOf(req).FieldByName("Auth").Interface().(mantis.Auth)
+		u, err := s.authenticate(auth)
+		if err != nil {
+			return nil, err
+		}
+		return fn(s, u, req)
+	}
+}
+
+// typed is a value with an xsi:type attribute.
+type typed struct {
+	Value any
+	Type  string
+}
+
+func (t typed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xsi:type"}, Value: t.Type})
+	return e.EncodeElement(t.Value, start)
+}
+
+func xsdInteger(i int) typed      { return typed{Value: i, Type: "xsd:integer"} }
+func xsdBoolean(b bool) typed     { return typed{Value: b, Type: "xsd:boolean"} }
+func xsdString(s string) typed    { return typed{Value: s, Type: "xsd:string"} }
+func ns1(typ string, v any) typed { return typed{Value: v, Type: "ns1:" + typ} }
+
+// soapArray is a SOAP-ENC:Array of the given item type.
+type soapArray[T any] struct {
+	Type  string
+	Items []T
+}
+
+func arrayOf[T any](typ string, items []T) soapArray[T] {
+	return soapArray[T]{Type: typ, Items: items}
+}
+
+func (a soapArray[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "SOAP-ENC:arrayType"}, Value: fmt.Sprintf("%s[%d]", a.Type, len(a.Items))},
+		xml.Attr{Name: xml.Name{Local: "xsi:type"}, Value: "SOAP-ENC:Array"},
+	)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	item := xml.StartElement{Name: xml.Name{Local: "item"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xsi:type"}, Value: a.Type}}}
+	for _, v := range a.Items {
+		if err := e.EncodeElement(v, item); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	u := s.restUser(r)
+	if u == nil {
+		http.Error(w, "API token required", http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, RESTPath)
+	writeJSON := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	switch {
+	case path == "/users/me" && r.Method == http.MethodGet:
+		writeJSON(u.AccountData)
+
+	case (path == "/users/me/token" || strings.HasPrefix(path, "/users/me/token/")) && r.Method == http.MethodPost:
+		var req struct {
+			Name string `json:"name"`
+		}
+		if b, _ := io.ReadAll(r.Body); len(b) != 0 {
+			if err := json.Unmarshal(b, &req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if req.Name == "" {
+			req.Name = strings.TrimPrefix(strings.TrimPrefix(path, "/users/me/token"), "/")
+		}
+		s.mu.Lock()
+		if _, ok := u.tokens[req.Name]; ok {
+			s.mu.Unlock()
+			http.Error(w, fmt.Sprintf("Token name '%s' already used.", req.Name), http.StatusBadRequest)
+			return
+		}
+		tok := s.createToken(u, req.Name)
+		id := u.tokens[req.Name].ID
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(struct {
+			User  mantis.AccountData `json:"user"`
+			Name  string             `json:"name"`
+			Token string             `json:"token"`
+			ID    int                `json:"id"`
+		}{User: u.AccountData, Name: req.Name, Token: tok, ID: id})
+
+	case strings.HasPrefix(path, "/users/me/token/") && r.Method == http.MethodDelete:
+		name := strings.TrimPrefix(path, "/users/me/token/")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for k, t := range u.tokens {
+			if k == name || fmt.Sprintf("%d", t.ID) == name {
+				delete(u.tokens, k)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		http.Error(w, fmt.Sprintf("Token '%s' not found.", name), http.StatusNotFound)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// restUser authenticates the REST request with an API token or Basic credentials.
+func (s *Server) restUser(r *http.Request) *user {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if username, password, ok := r.BasicAuth(); ok {
+		u, _ := s.authenticate(mantis.Auth{Username: username, Password: password})
+		return u
+	}
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		return nil
+	}
+	for _, u := range s.users {
+		for _, t := range u.tokens {
+			if t.Token == token {
+				return u
+			}
+		}
+	}
+	return nil
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,157 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantistest_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj", Enabled: true}, 0)
+	if err := srv.AddCategory(projectID, "general"); err != nil {
+		t.Fatal(err)
+	}
+	if cl.User.Name != mantistest.DefaultUser {
+		t.Errorf("logged in as %+v", cl.User)
+	}
+
+	projects, err := cl.ProjectsGetUserAccessible(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].ID != projectID {
+		t.Errorf("got %+v, wanted only %d", projects, projectID)
+	}
+	categories, err := cl.GetCategoriesForProject(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(categories.Categories, []string{"general"}) {
+		t.Errorf("got categories %q", categories.Categories)
+	}
+
+	summary, description, category := "summary", "description", "general"
+	issueID, err := cl.IssueAdd(ctx, mantis.IssueData{
+		Project: &mantis.ObjectRef{Name: "proj"}, Category: &category,
+		Summary: &summary, Description: &description,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cl.IssueNoteAdd(ctx, issueID, mantis.IssueNoteData{Text: "first note"}); err != nil {
+		t.Fatal(err)
+	}
+	const content = "árvíztűrő tükörfúrógép"
+	attID, err := cl.IssueAttachmentAdd(ctx, issueID, "a.txt", "text/plain", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := srv.AttachmentContent(attID); !ok || string(b) != content {
+		t.Errorf("attachment content: got %q, wanted %q", b, content)
+	}
+
+	issue, err := cl.IssueGet(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID == nil || int(*issue.ID) != issueID || *issue.Summary != summary ||
+		issue.Status.Name != "new" || issue.Reporter.Name != mantistest.DefaultUser {
+		t.Errorf("got %+v", issue)
+	}
+	if len(issue.Notes) != 1 || issue.Notes[0].Text != "first note" {
+		t.Errorf("got notes %+v", issue.Notes)
+	}
+	if len(issue.Attachments) != 1 || issue.Attachments[0].Size != len(content) {
+		t.Errorf("got attachments %+v", issue.Attachments)
+	}
+
+	issue.Status = &mantis.ObjectRef{ID: 80}
+	issue.CustomFields, issue.Attachments, issue.Notes = nil, nil, nil
+	if _, err = cl.IssueUpdate(ctx, issueID, issue); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ = srv.Issue(issueID); issue.Status.Name != "resolved" {
+		t.Errorf("status not updated: %+v", issue.Status)
+	}
+
+	ids, err := cl.FilterSearchIssueIDs(ctx, mantis.FilterSearchData{StatusID: []int{80}}, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []int{issueID}) {
+		t.Errorf("search: got %v, wanted [%d]", ids, issueID)
+	}
+
+	// The closed issues are hidden by default, as in Mantis, but not with META_FILTER_NONE.
+	issue.Status = &mantis.ObjectRef{ID: 90}
+	if _, err = cl.IssueUpdate(ctx, issueID, issue); err != nil {
+		t.Fatal(err)
+	}
+	if ids, err = cl.FilterSearchIssueIDs(ctx, mantis.FilterSearchData{}, 1, 10); err != nil || len(ids) != 0 {
+		t.Errorf("search closed: got %v, %+v, wanted none", ids, err)
+	}
+	if ids, err = cl.FilterSearchIssueIDs(ctx, mantis.FilterSearchData{HideStatusID: []int{-2}}, 1, 10); err != nil ||
+		!slices.Equal(ids, []int{issueID}) {
+		t.Errorf("search closed: got %v, %+v, wanted [%d]", ids, err, issueID)
+	}
+	if ok, err := cl.IssueExists(ctx, issueID+1); err != nil || ok {
+		t.Errorf("IssueExists(%d): %t, %+v", issueID+1, ok, err)
+	}
+	if _, err = cl.IssueGet(ctx, issueID+1); err == nil {
+		t.Errorf("IssueGet(%d) succeeded", issueID+1)
+	}
+
+	versionID, err := cl.ProjectVersionAdd(ctx, projectID, "1.0", "first", false, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions, err := cl.ProjectVersionsList(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || versions[0].ID != versionID || versions[0].Name != "1.0" {
+		t.Errorf("got versions %+v", versions)
+	}
+	statuses, err := cl.StatusEnum(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) == 0 || statuses[0].Name != "new" {
+		t.Errorf("got statuses %+v", statuses)
+	}
+}
+
+func TestServerAuth(t *testing.T) {
+	ctx := context.Background()
+	srv, _ := mantistest.Start(t)
+
+	if _, err := mantis.NewWithHTTPClient(ctx, &http.Client{}, srv.URL, mantistest.DefaultUser, "bad"); err == nil {
+		t.Error("login succeeded with bad password")
+	}
+
+	srv.AddUser(mantis.AccountData{Name: "viewer"}, "secret", mantistest.Viewer)
+	token, err := srv.APIToken("viewer", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := srv.Login(t, nil, "viewer", token)
+	if cl.User.Name != "viewer" {
+		t.Errorf("REST login: got %+v", cl.User)
+	}
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	if _, err := cl.ProjectVersionAdd(ctx, projectID, "1.0", "", false, false, nil); err == nil {
+		t.Error("viewer could add a version")
+	}
+}
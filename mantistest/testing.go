@@ -0,0 +1,61 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantistest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+)
+
+// Start starts a new Server for the test, closed when the test finishes,
+// and returns it with a Client logged in as DefaultUser.
+func Start(t testing.TB) (*Server, mantis.Client) {
+	t.Helper()
+	s := NewServer()
+	t.Cleanup(s.Close)
+	return s, s.Login(t, nil, DefaultUser, DefaultPassword)
+}
+
+// Login returns a Client logged in as the user, using hc (a new http.Client if nil).
+// It fails the test if the login fails.
+func (s *Server) Login(t testing.TB, hc *http.Client, username, password string) mantis.Client {
+	t.Helper()
+	if hc == nil {
+		hc = &http.Client{}
+	}
+	cl, err := mantis.NewWithHTTPClient(context.Background(), hc, s.URL, username, password)
+	if err != nil {
+		t.Fatalf("log in to %s as %q: %+v", s.URL, username, err)
+	}
+	return cl
+}
+
+// NewIssue adds the issue to the project as AddIssue does, failing the test on error.
+//
+// The summary and the description default to "summary" and "description".
+func (s *Server) NewIssue(t testing.TB, projectID int, issue mantis.IssueData) int {
+	t.Helper()
+	if issue.Project == nil {
+		issue.Project = &mantis.ObjectRef{ID: projectID}
+	}
+	if issue.Summary == nil {
+		summary := "summary"
+		issue.Summary = &summary
+	}
+	if issue.Description == nil {
+		description := "description"
+		issue.Description = &description
+	}
+	id, err := s.AddIssue(issue)
+	if err != nil {
+		t.Fatalf("add issue to project %d: %+v", projectID, err)
+	}
+	return id
+}
+
+// vim: set fileencoding=utf-8 noet:
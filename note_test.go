@@ -0,0 +1,62 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssueNoteUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{})
+	minutes := 90
+	noteID, err := cl.IssueNoteAdd(ctx, issueID, mantis.IssueNoteData{
+		Text: "first", TimeTracking: &minutes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keepID, err := cl.IssueNoteAdd(ctx, issueID, mantis.IssueNoteData{Text: "second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = cl.IssueNoteUpdate(ctx, mantis.IssueNoteData{Text: "no ID"}); err == nil {
+		t.Error("got nil error for a note without ID")
+	}
+	if err = cl.IssueNoteUpdate(ctx, mantis.IssueNoteData{
+		ID: &noteID, Text: "edited", ViewState: &mantis.ObjectRef{Name: "private"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := cl.IssueGet(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issue.Notes) != 2 {
+		t.Fatalf("got %d notes, wanted 2", len(issue.Notes))
+	}
+	if n := issue.Notes[0]; n.Text != "edited" || n.ViewState == nil || n.ViewState.ID != 50 || n.TimeTracking != 90 {
+		t.Errorf("got %+v", n)
+	}
+
+	if err = cl.IssueNoteDelete(ctx, noteID); err != nil {
+		t.Fatal(err)
+	}
+	if issue, err = cl.IssueGet(ctx, issueID); err != nil {
+		t.Fatal(err)
+	}
+	if len(issue.Notes) != 1 || issue.Notes[0].ID != keepID {
+		t.Errorf("got %+v, wanted only %d", issue.Notes, keepID)
+	}
+	if err = cl.IssueNoteDelete(ctx, noteID); err == nil {
+		t.Error("got nil error for deleting a deleted note")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPerPage is the page size used by the All* iterators for non-positive perPage.
+const DefaultPerPage = 100
+
+// AllProjectIssues iterates over all the issues of the project, fetching perPage issues at a time.
+func (c Client) AllProjectIssues(ctx context.Context, projectID, perPage int) iter.Seq2[IssueData, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]IssueData, error) {
+			return c.ProjectIssues(ctx, projectID, page, perPage)
+		},
+		func(issue IssueData) int {
+			if issue.ID == nil {
+				return 0
+			}
+			return int(*issue.ID)
+		})
+}
+
+// AllFilterSearchIssueIDs iterates over all the IDs of the issues matching the filter,
+// fetching perPage IDs at a time.
+func (c Client) AllFilterSearchIssueIDs(ctx context.Context, filter FilterSearchData, perPage int) iter.Seq2[int, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]int, error) {
+			return c.FilterSearchIssueIDs(ctx, filter, page, perPage)
+		},
+		func(id int) int { return id })
+}
+
+// paginate calls fetch with the increasing (1-based) page numbers until exhaustion.
+//
+// Mantis returns the last page again for page numbers after the last,
+// so an already seen key (as returned by key) is not yielded again,
+// and a page without new items ends the iteration.
+func paginate[T any](ctx context.Context, perPage int,
+	fetch func(ctx context.Context, page, perPage int) ([]T, error),
+	key func(T) int,
+) iter.Seq2[T, error] {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := make(map[int]struct{})
+		for page := 1; ; page++ {
+			items, err := fetch(ctx, page, perPage)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			var n int
+			for _, item := range items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				k := key(item)
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+				n++
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if n == 0 || len(items) < perPage {
+				return
+			}
+		}
+	}
+}
+
+// vim: set fileencoding=utf-8 noet:
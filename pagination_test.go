@@ -0,0 +1,77 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestPagination(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	var want []int
+	for range 5 {
+		want = append(want, srv.NewIssue(t, projectID, mantis.IssueData{}))
+	}
+
+	var ids []int
+	for id, err := range cl.AllFilterSearchIssueIDs(ctx, mantis.FilterSearchData{ProjectID: []int{projectID}}, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, want) {
+		t.Errorf("AllFilterSearchIssueIDs: got %v, wanted %v", ids, want)
+	}
+
+	// 4 issues fill 2 pages exactly, and the server repeats the last page past the end.
+	exactID := srv.AddProject(mantis.ProjectData{Name: "exact"}, 0)
+	var exact []int
+	for range 4 {
+		exact = append(exact, srv.NewIssue(t, exactID, mantis.IssueData{}))
+	}
+	ids = ids[:0]
+	seen := make(map[mantis.IssueID]bool)
+	for header, err := range cl.AllProjectIssueHeaders(ctx, exactID, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[header.ID] {
+			t.Errorf("AllProjectIssueHeaders: %d yielded twice", header.ID)
+		}
+		seen[header.ID] = true
+		ids = append(ids, int(header.ID))
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, exact) {
+		t.Errorf("AllProjectIssueHeaders: got %v, wanted %v", ids, exact)
+	}
+
+	ids = ids[:0]
+	for issue, err := range cl.AllProjectIssues(ctx, projectID, 3) {
+		if errors.Is(err, context.Canceled) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, int(*issue.ID))
+		if len(ids) == 4 {
+			cancel()
+		}
+	}
+	if len(ids) != 4 {
+		t.Errorf("AllProjectIssues after cancel: got %v", ids)
+	}
+}
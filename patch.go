@@ -0,0 +1,144 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// IssuePatch contains the changes of an issue: the nil fields are left as is.
+type IssuePatch struct {
+	// IfUnmodifiedSince, if not zero, makes IssuePatch return an ErrConflict
+	// if the issue has been updated after this time.
+	IfUnmodifiedSince time.Time
+
+	Project         *ObjectRef
+	Priority        *ObjectRef
+	Severity        *ObjectRef
+	Status          *ObjectRef
+	Reproducibility *ObjectRef
+	Projection      *ObjectRef
+	ETA             *ObjectRef
+	Resolution      *ObjectRef
+	ViewState       *ObjectRef
+	Handler         *AccountData
+
+	Category              *string
+	Summary               *string
+	Version               *string
+	Build                 *string
+	Platform              *string
+	Os                    *string
+	OsBuild               *string
+	FixedInVersion        *string
+	TargetVersion         *string
+	Description           *string
+	StepsToReproduce      *string
+	AdditionalInformation *string
+
+	DueDate *Time
+	Sticky  *bool
+
+	// CustomFields are the custom fields to set, the others are left as is.
+	CustomFields []CustomFieldData
+	// Monitors replaces the monitors of the issue, if not empty.
+	Monitors []AccountData
+	// Notes are added to the issue in the same update.
+	Notes []NoteData
+}
+
+// IssuePatch applies the patch to the issue: fetches it, merges the changes,
+// and sends it back without the read-only collections (attachments, relationships, tags),
+// with only the new notes and the changed custom fields, which are validated first.
+//
+// The IfUnmodifiedSince check happens between the fetch and the update,
+// so it cannot exclude a concurrent change in that short period.
+func (c Client) IssuePatch(ctx context.Context, issueID int, patch IssuePatch) error {
+	issue, err := c.IssueGet(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if !patch.IfUnmodifiedSince.IsZero() && issue.LastUpdated != nil &&
+		time.Time(*issue.LastUpdated).After(patch.IfUnmodifiedSince) {
+		return fmt.Errorf("issue %d was updated at %s, after %s: %w",
+			issueID, time.Time(*issue.LastUpdated).Format(time.RFC3339),
+			patch.IfUnmodifiedSince.Format(time.RFC3339), ErrConflict)
+	}
+	// Mantis would add the notes without reporter as user 0.
+	if len(patch.Notes) != 0 {
+		patch.Notes = append([]NoteData(nil), patch.Notes...)
+		for i, n := range patch.Notes {
+			if n.Reporter == (AccountData{}) {
+				patch.Notes[i].Reporter = c.User
+			}
+		}
+	}
+	updated := patch.Apply(issue)
+	if updated.Project != nil {
+		if err := c.validateCustomFields(ctx, updated.Project.ID, patch.CustomFields, false); err != nil {
+			return err
+		}
+	}
+	_, err = c.issueUpdate(ctx, issueID, updated)
+	return err
+}
+
+// Apply returns the issue with the patch applied,
+// without the read-only collections, with only the new notes and the changed custom fields.
+func (patch IssuePatch) Apply(issue IssueData) IssueData {
+	for _, x := range []struct{ dst, src **ObjectRef }{
+		{&issue.Project, &patch.Project},
+		{&issue.Priority, &patch.Priority},
+		{&issue.Severity, &patch.Severity},
+		{&issue.Status, &patch.Status},
+		{&issue.Reproducibility, &patch.Reproducibility},
+		{&issue.Projection, &patch.Projection},
+		{&issue.ETA, &patch.ETA},
+		{&issue.Resolution, &patch.Resolution},
+		{&issue.ViewState, &patch.ViewState},
+	} {
+		if *x.src != nil {
+			*x.dst = *x.src
+		}
+	}
+	for _, x := range []struct{ dst, src **string }{
+		{&issue.Category, &patch.Category},
+		{&issue.Summary, &patch.Summary},
+		{&issue.Version, &patch.Version},
+		{&issue.Build, &patch.Build},
+		{&issue.Platform, &patch.Platform},
+		{&issue.Os, &patch.Os},
+		{&issue.OsBuild, &patch.OsBuild},
+		{&issue.FixedInVersion, &patch.FixedInVersion},
+		{&issue.TargetVersion, &patch.TargetVersion},
+		{&issue.Description, &patch.Description},
+		{&issue.StepsToReproduce, &patch.StepsToReproduce},
+		{&issue.AdditionalInformation, &patch.AdditionalInformation},
+	} {
+		if *x.src != nil {
+			*x.dst = *x.src
+		}
+	}
+	if patch.Handler != nil {
+		issue.Handler = patch.Handler
+	}
+	if patch.DueDate != nil {
+		issue.DueDate = patch.DueDate
+	}
+	if patch.Sticky != nil {
+		issue.Sticky = patch.Sticky
+	}
+	// Mantis sets the monitors only if given.
+	issue.Monitors = patch.Monitors
+	issue.CustomFields = patch.CustomFields
+	// Mantis adds the notes without ID.
+	issue.Notes = patch.Notes
+	issue.Attachments, issue.Relationships, issue.Tags = nil, nil, nil
+	return issue
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,75 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssuePatch(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	srv.Now = func() time.Time { now = now.Add(time.Minute); return now }
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	for _, name := range []string{"a", "b"} {
+		if _, err := srv.AddCustomField(projectID, mantis.CustomFieldDefinitionData{Field: mantis.ObjectRef{Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{
+		CustomFields: []mantis.CustomFieldData{
+			{Field: mantis.ObjectRef{ID: 1, Name: "a"}, Value: "1"},
+			{Field: mantis.ObjectRef{ID: 2, Name: "b"}, Value: ""},
+		},
+	})
+	if _, err := srv.AddNote(issueID, mantis.IssueNoteData{Text: "note"}); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := cl.IssueGet(ctx, issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	since := time.Time(*issue.LastUpdated)
+
+	newSummary := "new summary"
+	if err = cl.IssuePatch(ctx, issueID, mantis.IssuePatch{
+		IfUnmodifiedSince: since,
+		Summary:           &newSummary,
+		Status:            &mantis.ObjectRef{ID: 50},
+		CustomFields:      []mantis.CustomFieldData{{Field: mantis.ObjectRef{Name: "b"}, Value: "2"}},
+		Notes:             []mantis.NoteData{{Text: "patched"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	issue, _ = srv.Issue(issueID)
+	if *issue.Summary != newSummary || issue.Status.ID != 50 || *issue.Description != "description" {
+		t.Errorf("got %+v", issue)
+	}
+	if len(issue.Notes) != 2 || issue.Notes[0].Text != "note" || issue.Notes[1].Text != "patched" {
+		t.Errorf("got notes %+v", issue.Notes)
+	}
+	if len(issue.CustomFields) != 2 || issue.CustomFields[0].Value != "1" || issue.CustomFields[1].Value != "2" {
+		t.Errorf("got custom fields %+v", issue.CustomFields)
+	}
+
+	// The issue has been changed after since.
+	oldSummary := "summary"
+	if err = cl.IssuePatch(ctx, issueID, mantis.IssuePatch{
+		IfUnmodifiedSince: since,
+		Summary:           &oldSummary,
+	}); !errors.Is(err, mantis.ErrConflict) {
+		t.Errorf("got %+v, wanted ErrConflict", err)
+	}
+	if issue, _ = srv.Issue(issueID); *issue.Summary != newSummary {
+		t.Errorf("summary changed to %q", *issue.Summary)
+	}
+}
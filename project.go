@@ -0,0 +1,75 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// AllProjects (ALL_PROJECTS) is the project ID of the global settings, such as the global categories.
+const AllProjects = 0
+
+// ProjectAdd creates the project, returning its ID.
+//
+// The SOAP API cannot create subprojects: the Subprojects are ignored.
+// A nil InheritGlobal inherits the global categories, as Mantis defaults to.
+func (c Client) ProjectAdd(ctx context.Context, project ProjectData) (int, error) {
+	var resp ProjectAddResponse
+	err := c.Call(ctx, "mc_project_add", ProjectAddRequest{Auth: c.auth, Project: project}, &resp)
+	return resp.Return, err
+}
+
+// ProjectUpdate updates the project. As Mantis sets all the fields, the project should be complete.
+func (c Client) ProjectUpdate(ctx context.Context, projectID int, project ProjectData) error {
+	var resp ProjectUpdateResponse
+	return c.Call(ctx, "mc_project_update",
+		ProjectUpdateRequest{Auth: c.auth, ProjectID: projectID, Project: project},
+		&resp)
+}
+
+// ProjectDelete deletes the project, with all its issues, versions and categories.
+func (c Client) ProjectDelete(ctx context.Context, projectID int) error {
+	var resp ProjectDeleteResponse
+	return c.Call(ctx, "mc_project_delete", ProjectDeleteRequest{Auth: c.auth, ProjectID: projectID}, &resp)
+}
+
+// ProjectGetAllSubprojects returns the IDs of all the subprojects of the project, recursively.
+func (c Client) ProjectGetAllSubprojects(ctx context.Context, projectID int) ([]int, error) {
+	var resp ProjectGetAllSubprojectsResponse
+	if err := c.Call(ctx, "mc_project_get_all_subprojects",
+		ProjectGetAllSubprojectsRequest{Auth: c.auth, ProjectID: projectID},
+		&resp,
+	); err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(resp.Return))
+	for _, s := range resp.Return {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return ids, fmt.Errorf("parse subproject ID %q: %w", s, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// ProjectGetIDFromName returns the ID of the project, or ErrProjectNotFound.
+func (c Client) ProjectGetIDFromName(ctx context.Context, name string) (int, error) {
+	var resp ProjectGetIDFromNameResponse
+	if err := c.Call(ctx, "mc_project_get_id_from_name",
+		ProjectGetIDFromNameRequest{Auth: c.auth, ProjectName: name},
+		&resp,
+	); err != nil {
+		return 0, err
+	}
+	if resp.Return == 0 {
+		return 0, fmt.Errorf("project %q: %w", name, ErrProjectNotFound)
+	}
+	return resp.Return, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
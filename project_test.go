@@ -0,0 +1,98 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestProjectAdmin(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+
+	projectID, err := cl.ProjectAdd(ctx, mantis.ProjectData{Name: "proj", Description: "first", Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cl.ProjectAdd(ctx, mantis.ProjectData{Name: "proj"}); err == nil {
+		t.Error("got nil error for a duplicate name")
+	}
+	subID := srv.AddProject(mantis.ProjectData{Name: "sub"}, projectID)
+	subSubID := srv.AddProject(mantis.ProjectData{Name: "subsub"}, subID)
+
+	if id, err := cl.ProjectGetIDFromName(ctx, "proj"); err != nil || id != projectID {
+		t.Errorf("got %d, %+v, wanted %d", id, err, projectID)
+	}
+	if _, err := cl.ProjectGetIDFromName(ctx, "nonexistent"); !errors.Is(err, mantis.ErrProjectNotFound) {
+		t.Errorf("got %+v, wanted ErrProjectNotFound", err)
+	}
+	ids, err := cl.ProjectGetAllSubprojects(ctx, projectID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, []int{subID, subSubID}) {
+		t.Errorf("got subprojects %v, wanted %v", ids, []int{subID, subSubID})
+	}
+
+	if err = cl.ProjectUpdate(ctx, projectID, mantis.ProjectData{
+		Name: "renamed", Description: "second", Enabled: true,
+		Status: &mantis.ObjectRef{Name: "stable"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	projects, err := cl.ProjectsGetUserAccessible(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 1 || projects[0].Name != "renamed" || projects[0].Description != "second" ||
+		projects[0].Status.ID != 50 || len(projects[0].Subprojects) != 1 {
+		t.Errorf("got %+v", projects)
+	}
+
+	if err = cl.ProjectDelete(ctx, projectID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ProjectGetIDFromName(ctx, "renamed"); !errors.Is(err, mantis.ErrProjectNotFound) {
+		t.Errorf("got %+v, wanted ErrProjectNotFound", err)
+	}
+	if err = cl.ProjectDelete(ctx, projectID); !errors.Is(err, mantis.ErrProjectNotFound) {
+		t.Errorf("got %+v, wanted ErrProjectNotFound", err)
+	}
+}
+
+func TestProjectInheritGlobal(t *testing.T) {
+	ctx := context.Background()
+	_, cl := mantistest.Start(t)
+
+	inherit := false
+	if _, err := cl.ProjectAdd(ctx, mantis.ProjectData{Name: "own", Enabled: true, InheritGlobal: &inherit}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.ProjectAdd(ctx, mantis.ProjectData{Name: "default", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	projects, err := cl.ProjectsGetUserAccessible(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		if p.InheritGlobal == nil {
+			t.Fatalf("%q: got nil InheritGlobal", p.Name)
+		}
+		got[p.Name] = *p.InheritGlobal
+	}
+	if want := map[string]bool{"own": false, "default": true}; !maps.Equal(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the secrets.
+const Redacted = "***"
+
+var rSecrets = []*regexp.Regexp{
+	// <password>...</password> of the SOAP requests
+	regexp.MustCompile(`(?is)(<(?:[\w-]+:)?password\b[^>]*>).*?(</(?:[\w-]+:)?password>)`),
+	// Authorization, Cookie headers, in header dumps and printed http.Header maps
+	regexp.MustCompile(`(?im)(\b(?:Authorization|Proxy-Authorization|Cookie|Set-Cookie)(?::\[|\s*[:=]\s*))[^\]\r\n]+()`),
+	// Mantis' session cookie
+	regexp.MustCompile(`(?i)(\bMANTIS_[A-Z_]*COOKIE=)[^;,&\s"'\]]+()`),
+	// password/token as %+v of structs, JSON or query parameter
+	regexp.MustCompile(`(?i)(\b(?:password|passwd|token)"?\s*[:=]\s*"?)[^\s,;&}"\]]+()`),
+}
+
+// Redact replaces the well-known secrets (passwords, API tokens,
+// Authorization headers and session cookies) in s.
+func Redact(s string) string {
+	for _, rx := range rSecrets {
+		s = rx.ReplaceAllString(s, "${1}"+Redacted+"${2}")
+	}
+	return s
+}
+
+// redactor replaces the well-known secret patterns and the given secret values.
+type redactor struct {
+	replacer *strings.Replacer
+}
+
+func newRedactor(secrets ...string) redactor {
+	oldnew := make([]string, 0, 2*len(secrets))
+	for _, s := range secrets {
+		if len(s) > 3 {
+			oldnew = append(oldnew, s, Redacted)
+		}
+	}
+	if len(oldnew) == 0 {
+		return redactor{}
+	}
+	return redactor{replacer: strings.NewReplacer(oldnew...)}
+}
+
+// authSecrets returns the secret forms of the credentials.
+func authSecrets(auth Auth) []string {
+	if auth.Password == "" {
+		return nil
+	}
+	basic := auth.Username + ":" + auth.Password
+	return []string{
+		base64.StdEncoding.EncodeToString([]byte(basic)),
+		base64.URLEncoding.EncodeToString([]byte(basic)),
+		auth.Password,
+	}
+}
+
+func (r redactor) Redact(s string) string {
+	if r.replacer != nil {
+		s = r.replacer.Replace(s)
+	}
+	return Redact(s)
+}
+
+// Error returns err with a redacted message, or nil if err is nil.
+// The original error is still reachable with errors.Is and errors.As.
+func (r redactor) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if red := r.Redact(msg); red != msg {
+		return &redactedError{err: err, msg: red}
+	}
+	return err
+}
+
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// Logger returns lgr with a redacting handler.
+func (r redactor) Logger(lgr *slog.Logger) *slog.Logger {
+	if lgr == nil {
+		return nil
+	}
+	if h, ok := lgr.Handler().(*RedactHandler); ok && h.redactor == r {
+		return lgr
+	}
+	return slog.New(&RedactHandler{Handler: lgr.Handler(), redactor: r})
+}
+
+// RedactHandler is a slog.Handler that redacts the secrets from the
+// messages and attributes, before passing the record to the wrapped Handler.
+type RedactHandler struct {
+	slog.Handler
+	redactor redactor
+}
+
+var _ slog.Handler = (*RedactHandler)(nil)
+
+// NewRedactHandler returns a RedactHandler for h,
+// which redacts the given secrets besides the well-known patterns.
+func NewRedactHandler(h slog.Handler, secrets ...string) *RedactHandler {
+	return &RedactHandler{Handler: h, redactor: newRedactor(secrets...)}
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	r2 := slog.NewRecord(r.Time, r.Level, h.redactor.Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		r2.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, r2)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	red := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		red[i] = h.redactAttr(a)
+	}
+	return &RedactHandler{Handler: h.Handler.WithAttrs(red), redactor: h.redactor}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
+
+func (h *RedactHandler) redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(h.redactor.Redact(a.Value.String()))
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		red := make([]slog.Attr, len(attrs))
+		for i, g := range attrs {
+			red[i] = h.redactAttr(g)
+		}
+		a.Value = slog.GroupValue(red...)
+	case slog.KindAny:
+		// Keep the structured value, unless its printed form contains secrets.
+		v := a.Value.Any()
+		var s string
+		switch x := v.(type) {
+		case error:
+			s = x.Error()
+		case []byte:
+			s = string(x)
+		default:
+			s = fmt.Sprintf("%+v", v)
+		}
+		if red := h.redactor.Redact(s); red != s {
+			a.Value = slog.StringValue(red)
+		}
+	}
+	return a
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,52 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	const secret = "s3cr3t-p4ss"
+	r := newRedactor(authSecrets(Auth{Username: "user", Password: secret})...)
+	for _, s := range []string{
+		`<mc_login xmlns="x"><username>user</username><password>` + secret + `</password></mc_login>`,
+		fmt.Sprintf("%v", http.Header{"Authorization": []string{"Basic dXNlcjpzM2NyM3QtcDRzcw=="}}),
+		"Authorization: 0123456789abcdef0123456789abcdef\r\n",
+		"Cookie: MANTIS_STRING_COOKIE=abcdef0123456789; PHPSESSID=x",
+		fmt.Sprintf("%+v", LoginRequest{Auth: Auth{Username: "user", Password: "another"}}),
+		`{"name":"x","token":"0123456789abcdef0123456789abcdef"}`,
+		"unrelated " + secret,
+	} {
+		got := r.Redact(s)
+		for _, leak := range []string{secret, "another", "dXNlcjpzM2NyM3QtcDRzcw==", "0123456789abcdef", "abcdef0123456789"} {
+			if strings.Contains(got, leak) {
+				t.Errorf("%q: %q remained in %q", s, leak, got)
+			}
+		}
+		if !strings.Contains(got, Redacted) {
+			t.Errorf("%q: nothing redacted", s)
+		}
+	}
+
+	wrapped := fmt.Errorf("call: %w", ErrAccessDenied)
+	err := r.Error(fmt.Errorf("%s: %w", secret, wrapped))
+	if strings.Contains(err.Error(), secret) || !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("got %q", err)
+	}
+
+	var buf bytes.Buffer
+	lgr := r.Logger(slog.New(slog.NewTextHandler(&buf, nil)))
+	lgr.With("password", secret).Info("login "+secret, "request", LoginRequest{Auth: Auth{Password: secret}}, "error", err)
+	if strings.Contains(buf.String(), secret) {
+		t.Errorf("secret logged: %s", buf.String())
+	}
+}
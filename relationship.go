@@ -0,0 +1,153 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RelationshipType is the type of a relationship between two issues,
+// as seen from the source issue.
+type RelationshipType int
+
+// The relationship types of Mantis (BUG_DUPLICATE, BUG_RELATED, BUG_DEPENDANT, BUG_BLOCKS, BUG_HAS_DUPLICATE).
+const (
+	DuplicateOf  = RelationshipType(0)
+	RelatedTo    = RelationshipType(1)
+	ParentOf     = RelationshipType(2)
+	ChildOf      = RelationshipType(3)
+	HasDuplicate = RelationshipType(4)
+)
+
+var relationshipNames = [...]string{
+	DuplicateOf:  "duplicate of",
+	RelatedTo:    "related to",
+	ParentOf:     "parent of",
+	ChildOf:      "child of",
+	HasDuplicate: "has duplicate",
+}
+
+// ParseRelationshipType parses the name of the relationship type,
+// accepting both "parent of" and "parent-of" forms.
+func ParseRelationshipType(s string) (RelationshipType, error) {
+	name := strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(strings.TrimSpace(s)))
+	for i, nm := range relationshipNames {
+		if nm == name {
+			return RelationshipType(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown relationship type %q", s)
+}
+
+// String returns the name of the relationship type, as Mantis names it.
+func (t RelationshipType) String() string {
+	if t < 0 || int(t) >= len(relationshipNames) {
+		return fmt.Sprintf("RelationshipType(%d)", int(t))
+	}
+	return relationshipNames[t]
+}
+
+// Reverse returns the type of the relationship as seen from the target issue.
+func (t RelationshipType) Reverse() RelationshipType {
+	switch t {
+	case DuplicateOf:
+		return HasDuplicate
+	case HasDuplicate:
+		return DuplicateOf
+	case ParentOf:
+		return ChildOf
+	case ChildOf:
+		return ParentOf
+	}
+	return t
+}
+
+// RelationshipType returns the type of the relationship.
+func (r RelationshipData) RelationshipType() RelationshipType {
+	return RelationshipType(r.Type.ID)
+}
+
+// IssueRelationshipAdd adds a relationship of the given type from issueID to targetID,
+// and returns the id of the new relationship.
+func (c Client) IssueRelationshipAdd(ctx context.Context, issueID int, typ RelationshipType, targetID int) (int, error) {
+	var resp IssueRelationshipAddResponse
+	if err := c.Call(ctx, "mc_issue_relationship_add",
+		IssueRelationshipAddRequest{Auth: c.auth, IssueID: IssueID(issueID),
+			Relationship: NewRelationshipData{
+				Type:     RelationshipTypeRef{ID: typ, Name: typ.String()},
+				TargetID: targetID,
+			}},
+		&resp,
+	); err != nil {
+		return 0, err
+	}
+	return resp.Return, nil
+}
+
+// IssueRelationshipDelete deletes the relationship of the issue.
+func (c Client) IssueRelationshipDelete(ctx context.Context, issueID, relationshipID int) error {
+	var resp IssueRelationshipDeleteResponse
+	return c.Call(ctx, "mc_issue_relationship_delete",
+		IssueRelationshipDeleteRequest{Auth: c.auth, IssueID: IssueID(issueID), RelationshipID: relationshipID},
+		&resp)
+}
+
+// RelationshipNode is an issue in the tree returned by IssueRelationshipTree.
+type RelationshipNode struct {
+	// Err is the error of getting the issue (ErrIssueNotFound or ErrAccessDenied);
+	// only the ID of the Issue is filled then.
+	Err   error     `json:"-"`
+	Issue IssueData `json:"issue"`
+	// Relationship is the relationship of the parent node to this issue,
+	// zero for the root.
+	Relationship RelationshipData    `json:"relationship"`
+	Children     []*RelationshipNode `json:"children,omitempty"`
+}
+
+// IssueRelationshipTree walks the relationships of the issue breadth-first, up to depth levels
+// (unlimited if depth is negative), and returns the tree of the related issues.
+//
+// Each issue appears only once in the tree, at its shallowest position.
+// Issues that does not exist or are not accessible are leaves, with Err set.
+func (c Client) IssueRelationshipTree(ctx context.Context, issueID, depth int) (*RelationshipNode, error) {
+	issue, err := c.IssueGet(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	root := &RelationshipNode{Issue: issue}
+	seen := map[int]struct{}{issueID: {}}
+	level := []*RelationshipNode{root}
+	for d := 0; len(level) != 0 && (depth < 0 || d < depth); d++ {
+		var next []*RelationshipNode
+		for _, parent := range level {
+			if parent.Err != nil {
+				continue
+			}
+			for _, rel := range parent.Issue.Relationships {
+				if _, ok := seen[rel.TargetID]; ok {
+					continue
+				}
+				seen[rel.TargetID] = struct{}{}
+				node := &RelationshipNode{Relationship: rel}
+				if node.Issue, err = c.IssueGet(ctx, rel.TargetID); err != nil {
+					if !errors.Is(err, ErrIssueNotFound) && !errors.Is(err, ErrAccessDenied) {
+						return root, err
+					}
+					id := IssueID(rel.TargetID)
+					node.Issue, node.Err = IssueData{ID: &id}, err
+				}
+				parent.Children = append(parent.Children, node)
+				next = append(next, node)
+			}
+		}
+		level = next
+	}
+	return root, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,104 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestParseRelationshipType(t *testing.T) {
+	for _, typ := range []mantis.RelationshipType{
+		mantis.DuplicateOf, mantis.RelatedTo, mantis.ParentOf, mantis.ChildOf, mantis.HasDuplicate,
+	} {
+		if got, err := mantis.ParseRelationshipType(typ.String()); err != nil || got != typ {
+			t.Errorf("%q: got %v, %+v", typ.String(), got, err)
+		}
+		if typ.Reverse().Reverse() != typ {
+			t.Errorf("%v: reverse of reverse is %v", typ, typ.Reverse().Reverse())
+		}
+	}
+	if got, err := mantis.ParseRelationshipType("Parent-Of"); err != nil || got != mantis.ParentOf {
+		t.Errorf("Parent-Of: got %v, %+v", got, err)
+	}
+	if _, err := mantis.ParseRelationshipType("sibling of"); err == nil {
+		t.Error("sibling of: no error")
+	}
+}
+
+func TestIssueRelationshipTree(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	ids := make([]int, 5)
+	for i := range ids {
+		ids[i] = srv.NewIssue(t, projectID, mantis.IssueData{})
+	}
+	// 0 -parent of-> 1 -parent of-> 2 -duplicate of-> 3; 0 -related to-> 2
+	for _, r := range []struct {
+		from int
+		typ  mantis.RelationshipType
+		to   int
+	}{
+		{0, mantis.ParentOf, 1}, {1, mantis.ParentOf, 2}, {2, mantis.DuplicateOf, 3}, {0, mantis.RelatedTo, 2},
+	} {
+		if _, err := cl.IssueRelationshipAdd(ctx, ids[r.from], r.typ, ids[r.to]); err != nil {
+			t.Fatalf("%d %v %d: %+v", r.from, r.typ, r.to, err)
+		}
+	}
+	if _, err := cl.IssueRelationshipAdd(ctx, ids[4], mantis.RelatedTo, ids[4]+100); !errors.Is(err, mantis.ErrIssueNotFound) {
+		t.Errorf("relate to a missing issue: got %+v", err)
+	}
+
+	tree, err := cl.IssueRelationshipTree(ctx, ids[0], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Children) != 2 || len(tree.Children[0].Children) != 0 {
+		t.Fatalf("depth 1: got %+v", tree)
+	}
+	tree, err = cl.IssueRelationshipTree(ctx, ids[0], -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2 is seen at depth 1 (related to 0), so 3 is under 2, and 1 has no children.
+	var child1, child2 *mantis.RelationshipNode
+	for _, c := range tree.Children {
+		switch int(*c.Issue.ID) {
+		case ids[1]:
+			child1 = c
+		case ids[2]:
+			child2 = c
+		}
+	}
+	if child1 == nil || child2 == nil || len(child1.Children) != 0 || len(child2.Children) != 1 {
+		t.Fatalf("got %+v", tree.Children)
+	}
+	if got := child1.Relationship.RelationshipType(); got != mantis.ParentOf {
+		t.Errorf("0->1: got %v", got)
+	}
+	if c := child2.Children[0]; int(*c.Issue.ID) != ids[3] || c.Relationship.RelationshipType() != mantis.DuplicateOf {
+		t.Errorf("2->3: got %+v", c)
+	}
+
+	// The reverse relationship is visible from the target, and deleting it removes both.
+	issue, err := cl.IssueGet(ctx, ids[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issue.Relationships) != 1 || issue.Relationships[0].RelationshipType() != mantis.HasDuplicate {
+		t.Fatalf("got %+v", issue.Relationships)
+	}
+	if err := cl.IssueRelationshipDelete(ctx, ids[3], issue.Relationships[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if issue, _ = srv.Issue(ids[2]); len(issue.Relationships) != 2 {
+		t.Errorf("after delete: got %+v", issue.Relationships)
+	}
+}
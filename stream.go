@@ -0,0 +1,195 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// The streaming calls bypass the Caller, to avoid buffering the (possibly huge)
+// attachment contents: the request is written, the response is read as a stream.
+
+const (
+	envelopeStart = xml.Header +
+		`<SOAP-ENV:Envelope xmlns:SOAP-ENV="http://schemas.xmlsoap.org/soap/envelope/"><SOAP-ENV:Body>`
+	envelopeEnd = `</SOAP-ENV:Body></SOAP-ENV:Envelope>`
+)
+
+// post sends the SOAP request, encoded on the fly into the request body,
+// and returns the successful response, or the *Fault for an error response.
+func (c Client) post(ctx context.Context, method string, request any) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	encErr := make(chan error, 1)
+	go func() {
+		bw := bufio.NewWriter(pw)
+		err := func() error {
+			if _, err := bw.WriteString(envelopeStart); err != nil {
+				return err
+			}
+			if err := xml.NewEncoder(bw).Encode(request); err != nil {
+				return fmt.Errorf("marshal %s request: %w", method, err)
+			}
+			if _, err := bw.WriteString(envelopeEnd); err != nil {
+				return err
+			}
+			return bw.Flush()
+		}()
+		encErr <- err
+		pw.CloseWithError(err)
+	}()
+	// Stop the encoder if the request failed before reading all the body.
+	defer pr.Close()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.soapURL, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", soapActionBase+method)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		select {
+		case encErr := <-encErr:
+			if encErr != nil {
+				return nil, encErr
+			}
+		default:
+		}
+		return nil, fmt.Errorf("call %s: %w", method, err)
+	}
+	if resp.StatusCode < 400 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxFaultSize))
+	if f := parseFault(b); f != nil {
+		f.Method = method
+		return nil, f
+	}
+	if len(b) > 1024 {
+		b = b[:1024]
+	}
+	return nil, fmt.Errorf("call %s: %s: %s", method, resp.Status, bytes.TrimSpace(b))
+}
+
+// callStream is like Call, but streams the request, so it can contain a huge Reader.
+func (c Client) callStream(ctx context.Context, method string, request, response any) error {
+	resp, err := c.post(ctx, method, request)
+	if err != nil {
+		return c.redactor.Error(err)
+	}
+	defer resp.Body.Close()
+	d := xml.NewDecoder(resp.Body)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return c.redactor.Error(fmt.Errorf("decode %s response: %w", method, err))
+		}
+		st, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch st.Name.Local {
+		case method + "Response":
+			if err := d.DecodeElement(response, &st); err != nil {
+				return c.redactor.Error(fmt.Errorf("decode %s response: %w", method, err))
+			}
+			return nil
+		case "Fault":
+			if f := decodeFault(d, st); f != nil {
+				f.Method = method
+				return c.redactor.Error(f)
+			}
+		}
+	}
+}
+
+// errNoReturn is returned when the response has no return element.
+var errNoReturn = errors.New("no return element in the response")
+
+// findReturn reads br till after the start tag of the return element,
+// so the content can be read directly from br.
+func findReturn(method string, br *bufio.Reader) error {
+	// The Decoder reads the io.ByteReader byte-by-byte, without buffering.
+	d := xml.NewDecoder(br)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errNoReturn
+			}
+			return fmt.Errorf("decode %s response: %w", method, err)
+		}
+		if st, ok := tok.(xml.StartElement); ok {
+			switch st.Name.Local {
+			case "return":
+				return nil
+			case "Fault":
+				if f := decodeFault(d, st); f != nil {
+					f.Method = method
+					return f
+				}
+			}
+		}
+	}
+}
+
+// xmlText reads the character data from the underlying reader till the next tag,
+// skipping the whitespace and the character references (which are whitespace in base64 content).
+type xmlText struct {
+	r    *bufio.Reader
+	done bool
+}
+
+func (t *xmlText) Read(p []byte) (int, error) {
+	if t.done {
+		return 0, io.EOF
+	}
+	var n int
+	for n < len(p) {
+		b, err := t.r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return n, err
+		}
+		switch b {
+		case '<':
+			t.done = true
+			if n == 0 {
+				return 0, io.EOF
+			}
+			return n, nil
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '&':
+			ref, err := t.r.ReadSlice(';')
+			if err != nil {
+				return n, fmt.Errorf("read character reference: %w", err)
+			}
+			if !strings.HasPrefix(string(ref), "#") {
+				return n, fmt.Errorf("unexpected entity &%s in base64 content", ref)
+			}
+			continue
+		}
+		p[n] = b
+		n++
+	}
+	return n, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
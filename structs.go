@@ -4,6 +4,8 @@
 
 //betteralign:ignore
 
+//go:generate go run ./cmd/mantisgen -o generated.go mantisconnect.wsdl
+
 package mantis
 
 import (
@@ -38,6 +40,62 @@ type FilterSearchIssueIDsResponse struct {
 	IDs     []IssueID `xml:"return>item"`
 }
 
+type ProjectAddRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_add"`
+	Auth
+	Project ProjectData `xml:"project"`
+}
+
+type ProjectAddResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_addResponse"`
+	Return  int      `xml:"return"`
+}
+
+type ProjectUpdateRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_update"`
+	Auth
+	ProjectID int         `xml:"project_id"`
+	Project   ProjectData `xml:"project"`
+}
+
+type ProjectUpdateResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_updateResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type ProjectDeleteRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_delete"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type ProjectDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type ProjectGetAllSubprojectsRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_all_subprojects"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type ProjectGetAllSubprojectsResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_all_subprojectsResponse"`
+	Return  []string `xml:"return>item"`
+}
+
+type ProjectGetIDFromNameRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_id_from_name"`
+	Auth
+	ProjectName string `xml:"project_name"`
+}
+
+type ProjectGetIDFromNameResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_id_from_nameResponse"`
+	Return  int      `xml:"return"`
+}
+
 type ProjectsGetUserAccessibleRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_projects_get_user_accessible"`
 	Auth
@@ -61,6 +119,68 @@ type ProjectIssuesResponse struct {
 	Issues  []IssueData `xml:"return>item"`
 }
 
+type ProjectGetIssueHeadersRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_issue_headers"`
+	Auth
+	ProjectID  int `xml:"project_id"`
+	PageNumber int `xml:"page_number"`
+	PerPage    int `xml:"per_page"`
+}
+
+type ProjectGetIssueHeadersResponse struct {
+	XMLName xml.Name          `xml:"http://futureware.biz/mantisconnect mc_project_get_issue_headersResponse"`
+	Return  []IssueHeaderData `xml:"return>item"`
+}
+
+type FilterGetIssueHeadersRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_filter_get_issue_headers"`
+	Auth
+	ProjectID  int `xml:"project_id"`
+	FilterID   int `xml:"filter_id"`
+	PageNumber int `xml:"page_number"`
+	PerPage    int `xml:"per_page"`
+}
+
+type FilterGetIssueHeadersResponse struct {
+	XMLName xml.Name          `xml:"http://futureware.biz/mantisconnect mc_filter_get_issue_headersResponse"`
+	Return  []IssueHeaderData `xml:"return>item"`
+}
+
+type FilterSearchIssueHeadersRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_filter_search_issue_headers"`
+	Auth
+	Filter     FilterSearchData `xml:"filter"`
+	PageNumber int              `xml:"page_number"`
+	PerPage    int              `xml:"per_page"`
+}
+
+type FilterSearchIssueHeadersResponse struct {
+	XMLName xml.Name          `xml:"http://futureware.biz/mantisconnect mc_filter_search_issue_headersResponse"`
+	Return  []IssueHeaderData `xml:"return>item"`
+}
+
+type IssuesGetRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issues_get"`
+	Auth
+	IssueIDs []int `xml:"issue_ids>item"`
+}
+
+type IssuesGetResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_issues_getResponse"`
+	Return  []IssueData `xml:"return>item"`
+}
+
+type IssuesGetHeaderRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issues_get_header"`
+	Auth
+	IssueIDs []int `xml:"issue_ids>item"`
+}
+
+type IssuesGetHeaderResponse struct {
+	XMLName xml.Name          `xml:"http://futureware.biz/mantisconnect mc_issues_get_headerResponse"`
+	Return  []IssueHeaderData `xml:"return>item"`
+}
+
 type IssueAddRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_add"`
 	Auth
@@ -98,6 +218,28 @@ type IssueAttachmentAddResponse struct {
 	Return  int      `xml:"return"`
 }
 
+type IssueAttachmentGetRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_attachment_get"`
+	Auth
+	IssueAttachmentID int `xml:"issue_attachment_id"`
+}
+
+type ProjectAttachmentAddRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_add"`
+	Auth
+	ProjectID   int    `xml:"project_id"`
+	Name        string `xml:"name"`
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	FileType    string `xml:"file_type"`
+	Content     Reader `xml:"content"`
+}
+
+type ProjectAttachmentAddResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_attachment_addResponse"`
+	Return  int      `xml:"return"`
+}
+
 type IssueNoteAddRequest struct { //betteralign:ignore
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_note_add"`
 	Auth
@@ -109,6 +251,59 @@ type IssueNoteAddResponse struct {
 	Return  int      `xml:"return"`
 }
 
+type IssueNoteUpdateRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_note_update"`
+	Auth
+	Note IssueNoteData `xml:"note"`
+}
+type IssueNoteUpdateResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_note_updateResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type IssueNoteDeleteRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_note_delete"`
+	Auth
+	IssueNoteID int `xml:"issue_note_id"`
+}
+type IssueNoteDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_note_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type IssueRelationshipAddRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_relationship_add"`
+	Auth
+	IssueID      IssueID             `xml:"issue_id"`
+	Relationship NewRelationshipData `xml:"relationship"`
+}
+type IssueRelationshipAddResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_relationship_addResponse"`
+	Return  int      `xml:"return"`
+}
+
+type IssueRelationshipDeleteRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_relationship_delete"`
+	Auth
+	IssueID        IssueID `xml:"issue_id"`
+	RelationshipID int     `xml:"relationship_id"`
+}
+type IssueRelationshipDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_relationship_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type IssueSetTagsRequest struct { //betteralign:ignore
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_set_tags"`
+	Auth
+	IssueID IssueID   `xml:"issue_id"`
+	Tags    []TagData `xml:"tags>item"`
+}
+type IssueSetTagsResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_set_tagsResponse"`
+	Return  bool     `xml:"return"`
+}
+
 type IssueGetRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get"`
 	Auth
@@ -129,6 +324,16 @@ type IssueExistsResponse struct {
 	Return bool `xml:"return"`
 }
 
+type IssueGetHistoryRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_issue_get_history"`
+	Auth
+	IssueID IssueID `xml:"issue_id"`
+}
+type IssueGetHistoryResponse struct {
+	XMLName xml.Name      `xml:"http://futureware.biz/mantisconnect mc_issue_get_historyResponse"`
+	Return  []HistoryData `xml:"return>item"`
+}
+
 type ProjectGetVersionsRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_versions"`
 	Auth
@@ -159,7 +364,7 @@ type ProjectVersionUpdateResponse struct {
 }
 
 type ProjectVersionDeleteRequest struct {
-	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_version_deleteRequest"`
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_version_delete"`
 	Auth
 	VersionID int `xml:"version_id"`
 }
@@ -169,7 +374,7 @@ type ProjectVersionDeleteResponse struct {
 }
 
 type ProjectGetReleasedVersionsRequest struct {
-	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_released_versionsRequest"`
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_released_versions"`
 	Auth
 	ProjectID int `xml:"project_id"`
 }
@@ -179,7 +384,7 @@ type ProjectGetReleasedVersionsResponse struct {
 }
 
 type ProjectGetUnreleasedVersionsRequest struct {
-	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_unreleased_versionsRequest"`
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_unreleased_versions"`
 	Auth
 	ProjectID int `xml:"project_id"`
 }
@@ -188,6 +393,37 @@ type ProjectGetUnreleasedVersionsResponse struct {
 	Return  []ProjectVersionData `xml:"return>item"`
 }
 
+type TagGetAllRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_tag_get_all"`
+	Auth
+	PageNumber int `xml:"page_number"`
+	PerPage    int `xml:"per_page"`
+}
+type TagGetAllResponse struct {
+	XMLName xml.Name            `xml:"http://futureware.biz/mantisconnect mc_tag_get_allResponse"`
+	Return  TagDataSearchResult `xml:"return"`
+}
+
+type TagAddRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_tag_add"`
+	Auth
+	Tag TagData `xml:"tag"`
+}
+type TagAddResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_tag_addResponse"`
+	Return  int      `xml:"return"`
+}
+
+type TagDeleteRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_tag_delete"`
+	Auth
+	TagID int `xml:"tag_id"`
+}
+type TagDeleteResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_tag_deleteResponse"`
+	Return  bool     `xml:"return"`
+}
+
 type StatusEnumRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_status"`
 	Auth
@@ -198,6 +434,127 @@ type StatusEnumResponse struct {
 	Statuses []ObjectRef `xml:"return>item"`
 }
 
+type PriorityEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_priorities"`
+	Auth
+}
+
+type PriorityEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_prioritiesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type SeverityEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_severities"`
+	Auth
+}
+
+type SeverityEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_severitiesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ReproducibilityEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_reproducibilities"`
+	Auth
+}
+
+type ReproducibilityEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_reproducibilitiesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ProjectionEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_projections"`
+	Auth
+}
+
+type ProjectionEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_projectionsResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ETAEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_etas"`
+	Auth
+}
+
+type ETAEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_etasResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ResolutionEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_resolutions"`
+	Auth
+}
+
+type ResolutionEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_resolutionsResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type AccessLevelEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_access_levels"`
+	Auth
+}
+
+type AccessLevelEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_access_levelsResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ProjectStatusEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_project_status"`
+	Auth
+}
+
+type ProjectStatusEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_project_statusResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ProjectViewStateEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_project_view_states"`
+	Auth
+}
+
+type ProjectViewStateEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_project_view_statesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type ViewStateEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_view_states"`
+	Auth
+}
+
+type ViewStateEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_view_statesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type CustomFieldTypeEnumRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_custom_field_types"`
+	Auth
+}
+
+type CustomFieldTypeEnumResponse struct {
+	XMLName xml.Name    `xml:"http://futureware.biz/mantisconnect mc_enum_custom_field_typesResponse"`
+	Return  []ObjectRef `xml:"return>item"`
+}
+
+type EnumGetRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_get"`
+	Auth
+	Enumeration string `xml:"enumeration"`
+}
+
+type EnumGetResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_enum_getResponse"`
+	Return  string   `xml:"return"`
+}
+
 type LoginRequest struct {
 	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_login"`
 	Auth
@@ -267,6 +624,29 @@ type IssueData struct {
 	Tags                  []ObjectRef        `xml:"tags>item,omitempty"`
 }
 
+// IssueHeaderData is the lightweight form of IssueData, without the texts,
+// the notes and the attachments; the enums and the accounts are IDs.
+type IssueHeaderData struct {
+	ID               IssueID `xml:"id"`
+	ViewState        int     `xml:"view_state"`
+	LastUpdated      *Time   `xml:"last_updated,omitempty"`
+	Project          int     `xml:"project"`
+	Category         string  `xml:"category"`
+	Priority         int     `xml:"priority"`
+	Severity         int     `xml:"severity"`
+	Status           int     `xml:"status"`
+	Reporter         int     `xml:"reporter"`
+	Summary          string  `xml:"summary"`
+	Handler          int     `xml:"handler"`
+	Resolution       int     `xml:"resolution"`
+	AttachmentsCount int     `xml:"attachments_count"`
+	NotesCount       int     `xml:"notes_count"`
+}
+
+// MetaFilterNone (META_FILTER_NONE) as the HideStatusID hides no status:
+// without any, Mantis hides the closed issues (hide_status_default).
+const MetaFilterNone = -2
+
 type FilterSearchData struct {
 	ProjectID            []int               `xml:"project_id>integer,omitempty"`
 	Search               string              `xml:"search,omitempty"`
@@ -333,6 +713,18 @@ type RelationshipData struct { //betteralign:ignore
 	TargetID int       `xml:"target_id,omitempty"`
 }
 
+// NewRelationshipData is the RelationshipData of mc_issue_relationship_add,
+// which always sends the type's id, as 0 means "duplicate of".
+type NewRelationshipData struct { //betteralign:ignore
+	Type     RelationshipTypeRef `xml:"type"`
+	TargetID int                 `xml:"target_id"`
+}
+
+type RelationshipTypeRef struct { //betteralign:ignore
+	ID   RelationshipType `xml:"id"`
+	Name string           `xml:"name,omitempty"`
+}
+
 type NoteData struct { //betteralign:ignore
 	ID            int         `xml:"id,omitempty"`
 	Reporter      AccountData `xml:"reporter,omitempty"`
@@ -345,6 +737,63 @@ type NoteData struct { //betteralign:ignore
 	NoteAttr      string      `xml:"note_attr,omitempty"`
 }
 
+type HistoryData struct { //betteralign:ignore
+	// Date is the time of the change, as Unix seconds.
+	Date     int64       `xml:"date"`
+	UserID   int         `xml:"userid"`
+	Username string      `xml:"username"`
+	Field    string      `xml:"field"`
+	Type     HistoryType `xml:"type"`
+	OldValue string      `xml:"old_value"`
+	NewValue string      `xml:"new_value"`
+}
+
+type TagData struct { //betteralign:ignore
+	ID          int          `xml:"id,omitempty"`
+	User        *AccountData `xml:"user_id,omitempty"`
+	Name        string       `xml:"name,omitempty"`
+	Description string       `xml:"description,omitempty"`
+	DateCreated *Time        `xml:"date_created,omitempty"`
+	DateUpdated *Time        `xml:"date_updated,omitempty"`
+}
+
+type TagDataSearchResult struct {
+	Results      []TagData `xml:"results>item"`
+	TotalResults int       `xml:"total_results"`
+}
+
+type CustomFieldDefinitionData struct { //betteralign:ignore
+	Field           ObjectRef       `xml:"field"`
+	Type            CustomFieldType `xml:"type"`
+	PossibleValues  string          `xml:"possible_values,omitempty"`
+	DefaultValue    string          `xml:"default_value,omitempty"`
+	ValidRegexp     string          `xml:"valid_regexp,omitempty"`
+	AccessLevelR    int             `xml:"access_level_r,omitempty"`
+	AccessLevelRW   int             `xml:"access_level_rw,omitempty"`
+	LengthMin       int             `xml:"length_min,omitempty"`
+	LengthMax       int             `xml:"length_max,omitempty"`
+	Advanced        bool            `xml:"advanced,omitempty"`
+	DisplayReport   bool            `xml:"display_report,omitempty"`
+	DisplayUpdate   bool            `xml:"display_update,omitempty"`
+	DisplayResolved bool            `xml:"display_resolved,omitempty"`
+	DisplayClosed   bool            `xml:"display_closed,omitempty"`
+	RequireReport   bool            `xml:"require_report,omitempty"`
+	RequireUpdate   bool            `xml:"require_update,omitempty"`
+	RequireResolved bool            `xml:"require_resolved,omitempty"`
+	RequireClosed   bool            `xml:"require_closed,omitempty"`
+}
+
+type ProjectGetCustomFieldsRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_get_custom_fields"`
+	Auth
+	ProjectID int `xml:"project_id"`
+}
+
+type ProjectGetCustomFieldsResponse struct {
+	XMLName xml.Name                    `xml:"http://futureware.biz/mantisconnect mc_project_get_custom_fieldsResponse"`
+	Return  []CustomFieldDefinitionData `xml:"return>item"`
+}
+
 type CustomFieldData struct {
 	Field ObjectRef `xml:"field"`
 	Value string    `xml:"value"`
@@ -381,7 +830,7 @@ type ProjectData struct { //betteralign:ignore
 	FilePath      string        `xml:"file_path,omitempty"`
 	Description   string        `xml:"description,omitempty"`
 	Subprojects   []ProjectData `xml:"subprojects>item,omitempty"`
-	InheritGlobal bool          `xml:"inherit_global,omitempty"`
+	InheritGlobal *bool         `xml:"inherit_global,omitempty"`
 }
 
 type ProjectVersionData struct { //betteralign:ignore
@@ -405,6 +854,44 @@ type ProjectCategoriesResp struct {
 	Categories []string `xml:"return>item"`
 }
 
+type ProjectAddCategoryRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_add_category"`
+	Auth
+	ProjectID    int    `xml:"project_id"`
+	CategoryName string `xml:"p_category_name"`
+}
+
+type ProjectAddCategoryResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_add_categoryResponse"`
+	Return  int      `xml:"return"`
+}
+
+type ProjectDeleteCategoryRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_delete_category"`
+	Auth
+	ProjectID    int    `xml:"project_id"`
+	CategoryName string `xml:"p_category_name"`
+}
+
+type ProjectDeleteCategoryResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_delete_categoryResponse"`
+	Return  int      `xml:"return"`
+}
+
+type ProjectRenameCategoryByNameRequest struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_rename_category_by_name"`
+	Auth
+	ProjectID       int    `xml:"project_id"`
+	CategoryName    string `xml:"p_category_name"`
+	CategoryNameNew string `xml:"p_category_name_new"`
+	AssignedTo      int    `xml:"p_assigned_to"`
+}
+
+type ProjectRenameCategoryByNameResponse struct {
+	XMLName xml.Name `xml:"http://futureware.biz/mantisconnect mc_project_rename_category_by_nameResponse"`
+	Return  int      `xml:"return"`
+}
+
 type IssueID int
 
 func (id IssueID) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -0,0 +1,109 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// TagGetAll returns the pageNumber-th (1-based) page of the tags, and the number of all tags.
+func (c Client) TagGetAll(ctx context.Context, pageNumber, perPage int) ([]TagData, int, error) {
+	var resp TagGetAllResponse
+	if err := c.Call(ctx, "mc_tag_get_all",
+		TagGetAllRequest{Auth: c.auth, PageNumber: pageNumber, PerPage: perPage},
+		&resp,
+	); err != nil {
+		return nil, 0, err
+	}
+	return resp.Return.Results, resp.Return.TotalResults, nil
+}
+
+// AllTags iterates over all the tags, fetching perPage tags at a time.
+func (c Client) AllTags(ctx context.Context, perPage int) iter.Seq2[TagData, error] {
+	return paginate(ctx, perPage,
+		func(ctx context.Context, page, perPage int) ([]TagData, error) {
+			tags, _, err := c.TagGetAll(ctx, page, perPage)
+			return tags, err
+		},
+		func(tag TagData) int { return tag.ID })
+}
+
+// TagAdd creates a new tag, returning its ID.
+func (c Client) TagAdd(ctx context.Context, name, description string) (int, error) {
+	var resp TagAddResponse
+	if err := c.Call(ctx, "mc_tag_add",
+		TagAddRequest{Auth: c.auth, Tag: TagData{Name: name, Description: description}},
+		&resp,
+	); err != nil {
+		return 0, err
+	}
+	return resp.Return, nil
+}
+
+// TagDelete deletes the tag, detaching it from all issues.
+func (c Client) TagDelete(ctx context.Context, tagID int) error {
+	var resp TagDeleteResponse
+	return c.Call(ctx, "mc_tag_delete", TagDeleteRequest{Auth: c.auth, TagID: tagID}, &resp)
+}
+
+// IssueSetTags replaces the tags of the issue. The tags are identified by their ID.
+func (c Client) IssueSetTags(ctx context.Context, issueID int, tags []TagData) error {
+	var resp IssueSetTagsResponse
+	return c.Call(ctx, "mc_issue_set_tags",
+		IssueSetTagsRequest{Auth: c.auth, IssueID: IssueID(issueID), Tags: tags},
+		&resp)
+}
+
+// IssueEditTags attaches the add and detaches the remove tags (by name) to/from the issue,
+// creating the missing tags, and returns the new tags of the issue.
+func (c Client) IssueEditTags(ctx context.Context, issueID int, add, remove []string) ([]ObjectRef, error) {
+	issue, err := c.IssueGet(ctx, issueID)
+	if err != nil {
+		return nil, err
+	}
+	tags := slices.DeleteFunc(slices.Clone(issue.Tags), func(t ObjectRef) bool {
+		return slices.Contains(remove, t.Name)
+	})
+	var missing []string
+	for _, name := range add {
+		if name != "" && !slices.Contains(remove, name) && !slices.Contains(missing, name) &&
+			!slices.ContainsFunc(tags, func(t ObjectRef) bool { return t.Name == name }) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 && len(tags) == len(issue.Tags) {
+		return issue.Tags, nil
+	}
+	if len(missing) != 0 {
+		for tag, err := range c.AllTags(ctx, 0) {
+			if err != nil {
+				return nil, err
+			}
+			if i := slices.Index(missing, tag.Name); i >= 0 {
+				tags = append(tags, ObjectRef{ID: tag.ID, Name: tag.Name})
+				missing = slices.Delete(missing, i, i+1)
+			}
+		}
+		for _, name := range missing {
+			id, err := c.TagAdd(ctx, name, "")
+			if err != nil {
+				return nil, err
+			}
+			tags = append(tags, ObjectRef{ID: id, Name: name})
+		}
+	}
+	data := make([]TagData, len(tags))
+	for i, t := range tags {
+		data[i] = TagData{ID: t.ID, Name: t.Name}
+	}
+	if err := c.IssueSetTags(ctx, issueID, data); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// vim: set fileencoding=utf-8 noet:
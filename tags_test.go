@@ -0,0 +1,71 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestIssueEditTags(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	issueID := srv.NewIssue(t, projectID, mantis.IssueData{})
+	for _, name := range []string{"bar", "baz", "foo"} {
+		if _, err := srv.AddTag(name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tagNames := func(tags []mantis.ObjectRef) []string {
+		names := make([]string, len(tags))
+		for i, t := range tags {
+			names[i] = t.Name
+		}
+		slices.Sort(names)
+		return names
+	}
+	if _, err := cl.IssueEditTags(ctx, issueID, []string{"bar", "baz"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := cl.IssueEditTags(ctx, issueID, []string{"foo", "new"}, []string{"bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"baz", "foo", "new"}
+	if got := tagNames(tags); !slices.Equal(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	issue, _ := srv.Issue(issueID)
+	if got := tagNames(issue.Tags); !slices.Equal(got, want) {
+		t.Errorf("stored %q, wanted %q", got, want)
+	}
+
+	var all []string
+	var barID int
+	for tag, err := range cl.AllTags(ctx, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, tag.Name)
+		if tag.Name == "bar" {
+			barID = tag.ID
+		}
+	}
+	if want := []string{"bar", "baz", "foo", "new"}; !slices.Equal(all, want) {
+		t.Errorf("AllTags: got %q, wanted %q", all, want)
+	}
+	if err := cl.TagDelete(ctx, barID); err != nil {
+		t.Fatal(err)
+	}
+	if _, total, err := cl.TagGetAll(ctx, 1, 1); err != nil || total != 3 {
+		t.Errorf("TagGetAll after delete: total=%d, %+v", total, err)
+	}
+}
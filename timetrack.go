@@ -0,0 +1,146 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// TimeFilter selects the notes with time tracking.
+type TimeFilter struct {
+	// Since and Until bound the submission time of the notes (Since <= t < Until), if not zero.
+	Since, Until time.Time
+	// ProjectIDs are the projects (with their subprojects) to search, all if empty.
+	ProjectIDs []int
+	// Users are the names of the note reporters, all if empty.
+	Users []string
+}
+
+// TimeEntry is the time spent, as recorded in a note.
+type TimeEntry struct {
+	// Date is the submission time of the note, in the location of TimeFilter.Since.
+	Date    time.Time `json:"date"`
+	User    string    `json:"user"`
+	Summary string    `json:"summary"`
+	IssueID int       `json:"issue_id"`
+	NoteID  int       `json:"note_id"`
+	Minutes int       `json:"minutes"`
+}
+
+// TimeEntries iterates over the notes with time tracking matching the filter,
+// searching the issues perPage at a time.
+func (c Client) TimeEntries(ctx context.Context, filter TimeFilter, perPage int) iter.Seq2[TimeEntry, error] {
+	// Time is logged on the closed issues, too.
+	search := FilterSearchData{ProjectID: filter.ProjectIDs, HideStatusID: []int{MetaFilterNone}}
+	loc := time.Local
+	if !filter.Since.IsZero() {
+		loc = filter.Since.Location()
+		// Adding the note updates the issue. A day earlier, as Mantis uses its own time zone.
+		y, m, d := filter.Since.AddDate(0, 0, -1).Date()
+		month := int(m)
+		search.LastUpdateStartYear, search.LastUpdateStartMonth, search.LastUpdateStartDay = &y, &month, &d
+	}
+	return func(yield func(TimeEntry, error) bool) {
+		for issueID, err := range c.AllFilterSearchIssueIDs(ctx, search, perPage) {
+			if err != nil {
+				yield(TimeEntry{}, err)
+				return
+			}
+			issue, err := c.IssueGet(ctx, issueID)
+			if err != nil {
+				yield(TimeEntry{}, fmt.Errorf("get issue %d: %w", issueID, err))
+				return
+			}
+			var summary string
+			if issue.Summary != nil {
+				summary = *issue.Summary
+			}
+			for _, n := range issue.Notes {
+				t := time.Time(n.DateSubmitted)
+				if n.TimeTracking == 0 ||
+					(!filter.Since.IsZero() && t.Before(filter.Since)) ||
+					(!filter.Until.IsZero() && !t.Before(filter.Until)) ||
+					(len(filter.Users) != 0 && !slices.Contains(filter.Users, n.Reporter.Name)) {
+					continue
+				}
+				if !yield(TimeEntry{
+					Date: t.In(loc), User: n.Reporter.Name,
+					IssueID: issueID, Summary: summary,
+					NoteID: n.ID, Minutes: n.TimeTracking,
+				}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// TimeTotal is the time spent, summed by a key: the user, the issue ID or the day.
+type TimeTotal struct {
+	Key string `json:"key"`
+	// Label is the summary of the issue, for the issue totals.
+	Label   string `json:"label,omitempty"`
+	Minutes int    `json:"minutes"`
+}
+
+// TimeReport contains the time tracking entries and their totals.
+type TimeReport struct {
+	Entries []TimeEntry `json:"entries"`
+	ByUser  []TimeTotal `json:"by_user"`
+	ByIssue []TimeTotal `json:"by_issue"`
+	ByDay   []TimeTotal `json:"by_day"`
+	Minutes int         `json:"minutes"`
+}
+
+// TimeReport collects the time tracking entries matching the filter, and sums them.
+func (c Client) TimeReport(ctx context.Context, filter TimeFilter) (TimeReport, error) {
+	var entries []TimeEntry
+	for e, err := range c.TimeEntries(ctx, filter, DefaultPerPage) {
+		if err != nil {
+			return TimeReport{}, err
+		}
+		entries = append(entries, e)
+	}
+	return NewTimeReport(entries), nil
+}
+
+// NewTimeReport sums the entries per user, per issue and per day (as of the entry's Date),
+// ordering the entries by date, and the totals by their keys.
+func NewTimeReport(entries []TimeEntry) TimeReport {
+	entries = slices.Clone(entries)
+	slices.SortStableFunc(entries, func(a, b TimeEntry) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.NoteID, b.NoteID))
+	})
+	r := TimeReport{Entries: entries}
+	sum := func(totals []TimeTotal, key, label string, minutes int) []TimeTotal {
+		if i := slices.IndexFunc(totals, func(t TimeTotal) bool { return t.Key == key }); i >= 0 {
+			totals[i].Minutes += minutes
+			return totals
+		}
+		return append(totals, TimeTotal{Key: key, Label: label, Minutes: minutes})
+	}
+	for _, e := range entries {
+		r.Minutes += e.Minutes
+		r.ByUser = sum(r.ByUser, e.User, "", e.Minutes)
+		r.ByDay = sum(r.ByDay, e.Date.Format(time.DateOnly), "", e.Minutes)
+		r.ByIssue = sum(r.ByIssue, strconv.Itoa(e.IssueID), e.Summary, e.Minutes)
+	}
+	slices.SortFunc(r.ByUser, func(a, b TimeTotal) int { return cmp.Compare(a.Key, b.Key) })
+	// The days are already ordered; the issues are ordered by their ID.
+	slices.SortFunc(r.ByIssue, func(a, b TimeTotal) int {
+		i, _ := strconv.Atoi(a.Key)
+		j, _ := strconv.Atoi(b.Key)
+		return cmp.Compare(i, j)
+	})
+	return r
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,94 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestTimeReport(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	var now time.Time
+	srv.Now = func() time.Time { return now }
+	srv.AddUser(mantis.AccountData{Name: "bob"}, "secret", mantistest.Developer)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	otherID := srv.AddProject(mantis.ProjectData{Name: "other"}, 0)
+
+	now = time.Date(2026, 8, 31, 12, 0, 0, 0, time.UTC)
+	addIssue := func(projectID int, summary string, status int) int {
+		return srv.NewIssue(t, projectID, mantis.IssueData{Summary: &summary, Status: &mantis.ObjectRef{ID: status}})
+	}
+	// The time logged on closed issues counts, too.
+	first, second, other := addIssue(projectID, "first", 50), addIssue(projectID, "second", 90), addIssue(otherID, "other", 10)
+	for _, n := range []struct {
+		date    string
+		user    string
+		issueID int
+		minutes int
+	}{
+		{"2026-08-31", "bob", first, 600}, // before the month
+		{"2026-09-01", mantistest.DefaultUser, first, 90},
+		{"2026-09-01", "bob", second, 30},
+		{"2026-09-02", "bob", first, 60},
+		{"2026-09-02", mantistest.DefaultUser, first, 0}, // no time tracking
+		{"2026-09-03", "bob", other, 45},                 // other project
+		{"2026-10-01", "bob", first, 120},                // after the month
+	} {
+		now, _ = time.Parse(time.DateOnly, n.date)
+		now = now.Add(10 * time.Hour)
+		minutes := n.minutes
+		if _, err := srv.AddNote(n.issueID, mantis.IssueNoteData{
+			Reporter: mantis.AccountData{Name: n.user}, Text: "work", TimeTracking: &minutes,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	since := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	r, err := cl.TimeReport(ctx, mantis.TimeFilter{
+		Since: since, Until: since.AddDate(0, 1, 0), ProjectIDs: []int{projectID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Entries) != 3 || r.Minutes != 180 {
+		t.Fatalf("got %+v", r)
+	}
+	if r.Entries[0].IssueID != first || r.Entries[0].Minutes != 90 || r.Entries[0].Summary != "first" {
+		t.Errorf("got first entry %+v", r.Entries[0])
+	}
+	for _, tc := range []struct {
+		name      string
+		got, want []mantis.TimeTotal
+	}{
+		{"user", r.ByUser, []mantis.TimeTotal{{Key: mantistest.DefaultUser, Minutes: 90}, {Key: "bob", Minutes: 90}}},
+		{"issue", r.ByIssue, []mantis.TimeTotal{{Key: "1", Label: "first", Minutes: 150}, {Key: "2", Label: "second", Minutes: 30}}},
+		{"day", r.ByDay, []mantis.TimeTotal{{Key: "2026-09-01", Minutes: 120}, {Key: "2026-09-02", Minutes: 60}}},
+	} {
+		if len(tc.got) != len(tc.want) {
+			t.Errorf("%s: got %+v, wanted %+v", tc.name, tc.got, tc.want)
+			continue
+		}
+		for i := range tc.got {
+			if tc.got[i] != tc.want[i] {
+				t.Errorf("%s: got %+v, wanted %+v", tc.name, tc.got, tc.want)
+				break
+			}
+		}
+	}
+
+	if r, err = cl.TimeReport(ctx, mantis.TimeFilter{Since: since, Users: []string{"bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	if r.Minutes != 30+60+45+120 {
+		t.Errorf("got %d minutes for bob, wanted %d: %+v", r.Minutes, 30+60+45+120, r.Entries)
+	}
+}
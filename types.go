@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -74,6 +75,26 @@ func (t *Time) UnmarshalText(p []byte) error {
 	return err
 }
 
+// Base64 is binary content, base64-encoded in the XML.
+type Base64 []byte
+
+func (b Base64) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(base64.StdEncoding.EncodeToString(b), start)
+}
+
+func (b *Base64) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	p, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		return fmt.Errorf("base64-decode: %w", err)
+	}
+	*b = p
+	return nil
+}
+
 type Reader struct {
 	io.Reader
 }
@@ -83,6 +104,8 @@ func (r Reader) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 	pr, pw := io.Pipe()
+	// Stop the encoding goroutine on errors.
+	defer pr.Close()
 	go func() {
 		w := base64.NewEncoder(base64.StdEncoding, pw)
 		n, err := io.Copy(w, r.Reader)
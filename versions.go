@@ -0,0 +1,166 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DefaultResolvedStatus is the default of Mantis' bug_resolved_status_threshold:
+// the issues with at least this status are resolved.
+const DefaultResolvedStatus = 80
+
+// ProjectReleasedVersions returns the released, not obsolete versions of the project,
+// the latest first.
+func (c Client) ProjectReleasedVersions(ctx context.Context, projectID int) ([]ProjectVersionData, error) {
+	var resp ProjectGetReleasedVersionsResponse
+	err := c.Call(ctx, "mc_project_get_released_versions",
+		ProjectGetReleasedVersionsRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// ProjectUnreleasedVersions returns the unreleased, not obsolete versions of the project,
+// the latest first.
+func (c Client) ProjectUnreleasedVersions(ctx context.Context, projectID int) ([]ProjectVersionData, error) {
+	var resp ProjectGetUnreleasedVersionsResponse
+	err := c.Call(ctx, "mc_project_get_unreleased_versions",
+		ProjectGetUnreleasedVersionsRequest{Auth: c.auth, ProjectID: projectID},
+		&resp)
+	return resp.Return, err
+}
+
+// ReleaseOptions are the options of ReleaseVersion.
+type ReleaseOptions struct {
+	// Date is the release date, today if zero.
+	Date time.Time
+	// MoveTo is the version to move the open issues to;
+	// Next, or else the next unreleased version if empty.
+	MoveTo string
+	// Next, if not empty, is the name of the new unreleased version to create.
+	Next string
+	// ResolvedStatus is the first status of the finished issues, DefaultResolvedStatus if zero.
+	ResolvedStatus int
+}
+
+// VersionRelease is the result of ReleaseVersion.
+type VersionRelease struct {
+	// Version is the released version.
+	Version ProjectVersionData `json:"version"`
+	// MovedTo is the version the open issues have been moved to, empty if none.
+	MovedTo string `json:"moved_to,omitempty"`
+	// Moved are the IDs of the moved issues.
+	Moved []int `json:"moved,omitempty"`
+	// Open are the IDs of the open issues left targeting the released version,
+	// as there was no version to move them to.
+	Open []int `json:"open,omitempty"`
+	// NextID is the ID of the created next version, 0 if none.
+	NextID int `json:"next_id,omitempty"`
+}
+
+// ReleaseVersion marks the version of the project as released as of opts.Date,
+// after moving the still open issues targeting it to the next version - see ReleaseOptions.
+//
+// The version is not released if some of the issues could not be moved:
+// the errors are returned in an IssueErrors, and ReleaseVersion can be called again
+// (with MoveTo instead of Next, as the next version has already been created).
+func (c Client) ReleaseVersion(ctx context.Context, projectID int, name string, opts ReleaseOptions) (VersionRelease, error) {
+	var rel VersionRelease
+	versions, err := c.ProjectVersionsList(ctx, projectID)
+	if err != nil {
+		return rel, err
+	}
+	i := slices.IndexFunc(versions, func(v ProjectVersionData) bool { return v.Name == name })
+	if i < 0 {
+		return rel, fmt.Errorf("version %q of project %d: %w", name, projectID, ErrVersionNotFound)
+	}
+	rel.Version = versions[i]
+	if rel.Version.Released {
+		return rel, fmt.Errorf("version %q of project %d is already released", name, projectID)
+	}
+	if opts.Date.IsZero() {
+		y, m, d := time.Now().Date()
+		opts.Date = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+	if opts.ResolvedStatus <= 0 {
+		opts.ResolvedStatus = DefaultResolvedStatus
+	}
+
+	rel.MovedTo = cmp.Or(opts.MoveTo, opts.Next)
+	if opts.MoveTo != "" && opts.MoveTo != opts.Next &&
+		!slices.ContainsFunc(versions, func(v ProjectVersionData) bool { return v.Name == opts.MoveTo && v.ID != rel.Version.ID }) {
+		return rel, fmt.Errorf("version %q of project %d: %w", opts.MoveTo, projectID, ErrVersionNotFound)
+	}
+	if opts.Next != "" {
+		if slices.ContainsFunc(versions, func(v ProjectVersionData) bool { return v.Name == opts.Next }) {
+			return rel, fmt.Errorf("version %q of project %d already exists", opts.Next, projectID)
+		}
+		// Mantis orders the versions by their date.
+		date := Time(opts.Date.AddDate(0, 0, 1))
+		if rel.NextID, err = c.ProjectVersionAdd(ctx, projectID, opts.Next, "", false, false, &date); err != nil {
+			return rel, fmt.Errorf("add version %q: %w", opts.Next, err)
+		}
+	} else if opts.MoveTo == "" {
+		unreleased, err := c.ProjectUnreleasedVersions(ctx, projectID)
+		if err != nil {
+			return rel, err
+		}
+		unreleased = slices.DeleteFunc(unreleased, func(v ProjectVersionData) bool {
+			return v.ID == rel.Version.ID || v.ProjectID != projectID
+		})
+		// The earliest of the remaining unreleased versions.
+		if len(unreleased) != 0 {
+			rel.MovedTo = slices.MinFunc(unreleased, func(a, b ProjectVersionData) int {
+				return cmp.Or(versionDate(a).Compare(versionDate(b)), cmp.Compare(a.ID, b.ID))
+			}).Name
+		}
+	}
+
+	filter := FilterSearchData{ProjectID: []int{projectID},
+		TargetVersion: []string{name}, HideStatusID: []int{opts.ResolvedStatus}}
+	// Collected first, as the moved issues would shift the pages.
+	var open []int
+	for issueID, err := range c.AllFilterSearchIssueIDs(ctx, filter, DefaultPerPage) {
+		if err != nil {
+			return rel, err
+		}
+		open = append(open, issueID)
+	}
+	if rel.MovedTo == "" {
+		rel.Open, open = open, nil
+	}
+	errs := make(IssueErrors)
+	for _, issueID := range open {
+		if err := c.IssuePatch(ctx, issueID, IssuePatch{TargetVersion: &rel.MovedTo}); err != nil {
+			errs[issueID] = err
+			continue
+		}
+		rel.Moved = append(rel.Moved, issueID)
+	}
+	if len(errs) != 0 {
+		return rel, errs
+	}
+
+	date := Time(opts.Date)
+	rel.Version.Released, rel.Version.DateOrder = true, &date
+	if err := c.ProjectVersionUpdate(ctx, rel.Version); err != nil {
+		return rel, fmt.Errorf("release version %q: %w", name, err)
+	}
+	return rel, nil
+}
+
+// versionDate returns the date of the version, or the zero time if it has none.
+func versionDate(v ProjectVersionData) time.Time {
+	if v.DateOrder.IsZero() {
+		return time.Time{}
+	}
+	return time.Time(*v.DateOrder)
+}
+
+// vim: set fileencoding=utf-8 noet:
@@ -0,0 +1,109 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tgulacsi/mantis-soap"
+	"github.com/tgulacsi/mantis-soap/mantistest"
+)
+
+func TestReleaseVersion(t *testing.T) {
+	ctx := context.Background()
+	srv, cl := mantistest.Start(t)
+	projectID := srv.AddProject(mantis.ProjectData{Name: "proj"}, 0)
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, name := range []string{"0.9", "1.0", "1.1"} {
+		date := mantis.Time(day.AddDate(0, i, 0))
+		if _, err := srv.AddVersion(mantis.ProjectVersionData{ProjectID: projectID,
+			Name: name, DateOrder: &date, Released: name == "0.9"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addIssue := func(target string, status int) int {
+		t.Helper()
+		return srv.NewIssue(t, projectID, mantis.IssueData{
+			TargetVersion: &target, Status: &mantis.ObjectRef{ID: status}})
+	}
+	open, resolved, later := addIssue("1.0", 50), addIssue("1.0", 80), addIssue("1.1", 10)
+	names := func(versions []mantis.ProjectVersionData, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, v := range versions {
+			names = append(names, v.Name)
+		}
+		return names
+	}
+	target := func(issueID int) string {
+		t.Helper()
+		issue, ok := srv.Issue(issueID)
+		if !ok {
+			t.Fatalf("issue %d not found", issueID)
+		}
+		return *issue.TargetVersion
+	}
+
+	if got := names(cl.ProjectUnreleasedVersions(ctx, projectID)); !slices.Equal(got, []string{"1.1", "1.0"}) {
+		t.Errorf("unreleased: got %q", got)
+	}
+	releaseDate := day.AddDate(0, 1, 15)
+	rel, err := cl.ReleaseVersion(ctx, projectID, "1.0", mantis.ReleaseOptions{Date: releaseDate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.MovedTo != "1.1" || !slices.Equal(rel.Moved, []int{open}) || !rel.Version.Released {
+		t.Errorf("got %+v", rel)
+	}
+	if got := target(open); got != "1.1" {
+		t.Errorf("open issue targets %q, wanted 1.1", got)
+	}
+	if got := target(resolved); got != "1.0" {
+		t.Errorf("resolved issue targets %q, wanted 1.0", got)
+	}
+	released, err := cl.ProjectReleasedVersions(ctx, projectID)
+	if got := names(released, err); !slices.Equal(got, []string{"1.0", "0.9"}) {
+		t.Errorf("released: got %q", got)
+	}
+	if got := time.Time(*released[0].DateOrder); !got.Equal(releaseDate) {
+		t.Errorf("released at %s, wanted %s", got, releaseDate)
+	}
+	if _, err = cl.ReleaseVersion(ctx, projectID, "1.0", mantis.ReleaseOptions{}); err == nil {
+		t.Error("got nil error for releasing a released version")
+	}
+
+	if _, err = cl.ReleaseVersion(ctx, projectID, "1.1", mantis.ReleaseOptions{MoveTo: "nonexistent", Next: "1.2"}); !errors.Is(err, mantis.ErrVersionNotFound) {
+		t.Errorf("move to nonexistent: got %+v, wanted ErrVersionNotFound", err)
+	}
+	rel, err = cl.ReleaseVersion(ctx, projectID, "1.1", mantis.ReleaseOptions{Next: "1.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(rel.Moved)
+	if rel.MovedTo != "1.2" || rel.NextID == 0 || !slices.Equal(rel.Moved, []int{open, later}) {
+		t.Errorf("got %+v", rel)
+	}
+	if got := names(cl.ProjectUnreleasedVersions(ctx, projectID)); !slices.Equal(got, []string{"1.2"}) {
+		t.Errorf("unreleased: got %q", got)
+	}
+
+	rel, err = cl.ReleaseVersion(ctx, projectID, "1.2", mantis.ReleaseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.MovedTo != "" || len(rel.Open) != 2 {
+		t.Errorf("got %+v", rel)
+	}
+	if _, err = cl.ReleaseVersion(ctx, projectID, "nonexistent", mantis.ReleaseOptions{}); !errors.Is(err, mantis.ErrVersionNotFound) {
+		t.Errorf("got %+v, wanted ErrVersionNotFound", err)
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright 2026 Tamás Gulácsi
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mantis_test
+
+import (
+	"encoding/xml"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// nsMantis is the target namespace of mantisconnect.wsdl.
+const nsMantis = "http://futureware.biz/mantisconnect"
+
+// notInWSDL are the operations of newer Mantis versions, missing from the bundled WSDL.
+var notInWSDL = []string{"mc_user_token_create"}
+
+type wsdlDefinitions struct {
+	Messages []struct {
+		Name  string `xml:"name,attr"`
+		Parts []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"part"`
+	} `xml:"message"`
+	PortTypes []struct {
+		Operations []struct {
+			Name   string         `xml:"name,attr"`
+			Input  wsdlMessageRef `xml:"input"`
+			Output wsdlMessageRef `xml:"output"`
+		} `xml:"operation"`
+	} `xml:"portType"`
+	ComplexTypes []struct {
+		Name     string `xml:"name,attr"`
+		Elements []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"all>element"`
+	} `xml:"types>schema>complexType"`
+}
+
+type wsdlMessageRef struct {
+	Message string `xml:"message,attr"`
+}
+
+// wsdl is the parsed mantisconnect.wsdl: the parts of the messages,
+// the input and output messages of the operations and the elements of the complex types.
+type wsdl struct {
+	messages     map[string][]string
+	operations   map[string][2]string
+	complexTypes map[string][]string
+}
+
+func parseWSDL(t *testing.T) wsdl {
+	t.Helper()
+	fh, err := os.Open("mantisconnect.wsdl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+	d := xml.NewDecoder(fh)
+	// The file declares ISO-8859-1, but is ASCII.
+	d.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	var defs wsdlDefinitions
+	if err := d.Decode(&defs); err != nil {
+		t.Fatal(err)
+	}
+	w := wsdl{messages: make(map[string][]string), operations: make(map[string][2]string),
+		complexTypes: make(map[string][]string)}
+	for _, m := range defs.Messages {
+		parts := make([]string, len(m.Parts))
+		for i, p := range m.Parts {
+			parts[i] = p.Name
+		}
+		w.messages[m.Name] = parts
+	}
+	for _, pt := range defs.PortTypes {
+		for _, op := range pt.Operations {
+			w.operations[op.Name] = [2]string{
+				strings.TrimPrefix(op.Input.Message, "tns:"),
+				strings.TrimPrefix(op.Output.Message, "tns:"),
+			}
+		}
+	}
+	for _, ct := range defs.ComplexTypes {
+		if len(ct.Elements) == 0 {
+			continue
+		}
+		for _, e := range ct.Elements {
+			w.complexTypes[ct.Name] = append(w.complexTypes[ct.Name], e.Name)
+		}
+	}
+	return w
+}
+
+// goStruct is a struct type declared in structs.go or generated.go.
+type goStruct struct {
+	Name string
+	// Element is the local name of the XMLName tag, empty if the struct has none.
+	Element   string
+	Namespace string
+	// Fields are the first elements of the xml tags of the fields.
+	Fields []string
+	// Auth reports whether Auth is embedded.
+	Auth bool
+}
+
+func parseStructs(t *testing.T) []goStruct {
+	t.Helper()
+	var structs []goStruct
+	fset := token.NewFileSet()
+	for _, fn := range []string{"structs.go", "generated.go"} {
+		f, err := parser.ParseFile(fset, fn, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		structs = append(structs, fileStructs(t, f)...)
+	}
+	return structs
+}
+
+func fileStructs(t *testing.T, f *ast.File) []goStruct {
+	t.Helper()
+	var structs []goStruct
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		s := goStruct{Name: ts.Name.Name}
+		for _, field := range st.Fields.List {
+			if len(field.Names) == 0 {
+				if id, ok := field.Type.(*ast.Ident); ok && id.Name == "Auth" {
+					s.Auth = true
+				}
+				continue
+			}
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				t.Fatalf("%s.%s: %v", s.Name, field.Names[0].Name, err)
+			}
+			name, _, _ := strings.Cut(reflect.StructTag(tag).Get("xml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			if field.Names[0].Name == "XMLName" {
+				if ns, local, ok := strings.Cut(name, " "); ok {
+					s.Namespace, s.Element = ns, local
+				} else {
+					s.Element = name
+				}
+				continue
+			}
+			first, _, _ := strings.Cut(name, ">")
+			s.Fields = append(s.Fields, first)
+		}
+		structs = append(structs, s)
+		return false
+	})
+	return structs
+}
+
+func TestWSDLContract(t *testing.T) {
+	w := parseWSDL(t)
+	structs := parseStructs(t)
+	if len(w.operations) == 0 || len(structs) == 0 {
+		t.Fatalf("got %d operations and %d structs", len(w.operations), len(structs))
+	}
+
+	var n int
+	for _, s := range structs {
+		if s.Element == "" {
+			continue
+		}
+		n++
+		if s.Namespace != nsMantis {
+			t.Errorf("%s: namespace is %q, wanted %q", s.Name, s.Namespace, nsMantis)
+		}
+		op, isResponse := strings.CutSuffix(s.Element, "Response")
+		if slices.Contains(notInWSDL, op) {
+			continue
+		}
+		messages, ok := w.operations[op]
+		if !ok {
+			t.Errorf("%s: no operation %q in the WSDL (element %q)", s.Name, op, s.Element)
+			continue
+		}
+		message := messages[0]
+		if isResponse {
+			message = messages[1]
+		}
+		parts, ok := w.messages[message]
+		if !ok {
+			t.Errorf("%s: no message %q in the WSDL", s.Name, message)
+			continue
+		}
+		fields := s.Fields
+		if s.Auth {
+			fields = append([]string{"username", "password"}, fields...)
+		}
+		for _, f := range fields {
+			if !slices.Contains(parts, f) {
+				t.Errorf("%s: field %q is not a part of %s (%q)", s.Name, f, message, parts)
+			}
+		}
+		for _, p := range parts {
+			if !slices.Contains(fields, p) {
+				t.Errorf("%s: part %q of %s is missing", s.Name, p, message)
+			}
+		}
+	}
+	if n == 0 {
+		t.Error("no request/response structs found")
+	}
+
+	// The structs named after complex types have only their elements.
+	for _, s := range structs {
+		elements, ok := w.complexTypes[s.Name]
+		if !ok {
+			continue
+		}
+		for _, f := range s.Fields {
+			if !slices.Contains(elements, f) {
+				t.Errorf("%s: field %q is not an element of the complex type (%q)", s.Name, f, elements)
+			}
+		}
+	}
+}
+
+// TestCallOperations checks that the Client methods call the operation of their request:
+// through Call, and the streaming callStream and post.
+func TestCallOperations(t *testing.T) {
+	elements := make(map[string]string)
+	for _, s := range parseStructs(t) {
+		elements[s.Name] = s.Element
+	}
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The streaming calls must be found, too.
+	streaming := map[string]bool{
+		"mc_issue_attachment_add":   false,
+		"mc_project_attachment_add": false,
+		"mc_issue_attachment_get":   false,
+	}
+	fset := token.NewFileSet()
+	var n int
+	for _, fn := range files {
+		if strings.HasSuffix(fn, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, fn, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil {
+				continue
+			}
+			// The operation may be a local constant, as in IssueAttachmentGet.
+			consts := make(map[string]string)
+			ast.Inspect(fd.Body, func(node ast.Node) bool {
+				gd, ok := node.(*ast.GenDecl)
+				if !ok || gd.Tok != token.CONST {
+					return true
+				}
+				for _, spec := range gd.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, name := range vs.Names {
+						if i < len(vs.Values) {
+							if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+								consts[name.Name], _ = strconv.Unquote(lit.Value)
+							}
+						}
+					}
+				}
+				return true
+			})
+			ast.Inspect(fd.Body, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok || len(call.Args) < 3 {
+					return true
+				}
+				if sel, ok := call.Fun.(*ast.SelectorExpr); !ok ||
+					!(sel.Sel.Name == "Call" || sel.Sel.Name == "callStream" || sel.Sel.Name == "post") {
+					return true
+				}
+				var op string
+				switch arg := call.Args[1].(type) {
+				case *ast.BasicLit:
+					if arg.Kind != token.STRING {
+						return true
+					}
+					op, _ = strconv.Unquote(arg.Value)
+				case *ast.Ident:
+					if op, ok = consts[arg.Name]; !ok {
+						return true
+					}
+				default:
+					return true
+				}
+				req, ok := call.Args[2].(*ast.CompositeLit)
+				if !ok {
+					return true
+				}
+				typ, ok := req.Type.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				n++
+				if _, ok := streaming[op]; ok {
+					streaming[op] = true
+				}
+				if element := elements[typ.Name]; element != op {
+					t.Errorf("%s: calls %q with %s, whose element is %q", fset.Position(call.Pos()), op, typ.Name, element)
+				}
+				return true
+			})
+		}
+	}
+	if n == 0 {
+		t.Error("no calls found")
+	}
+	for op, found := range streaming {
+		if !found {
+			t.Errorf("streaming call of %s not found", op)
+		}
+	}
+}